@@ -0,0 +1,185 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// B3Format is an OpenTracing carrier format constant for the B3 propagation
+// scheme (https://github.com/openzipkin/b3-propagation). Register it with
+// TracerOptions.Injector/Extractor to allow a tracer to speak B3 alongside
+// its native format.
+const B3Format = "b3-propagation-format"
+
+const (
+	b3TraceIDHeader      = "x-b3-traceid"
+	b3SpanIDHeader       = "x-b3-spanid"
+	b3ParentSpanIDHeader = "x-b3-parentspanid"
+	b3SampledHeader      = "x-b3-sampled"
+	b3FlagsHeader        = "x-b3-flags"
+	b3SingleHeader       = "b3"
+)
+
+// B3Propagator is a combined Injector and Extractor for the B3 propagation
+// format. It can emit either the multi-header (X-B3-*) representation or
+// the single "b3" header representation; both are always accepted on
+// extraction.
+type B3Propagator struct {
+	singleHeader bool
+}
+
+// NewB3Propagator creates a B3Propagator. By default it injects the
+// multi-header representation; pass B3InjectSingleHeader(true) to emit the
+// single "b3" header instead.
+func NewB3Propagator(opts ...B3PropagatorOption) *B3Propagator {
+	p := &B3Propagator{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// B3PropagatorOption customizes a B3Propagator.
+type B3PropagatorOption func(*B3Propagator)
+
+// B3InjectSingleHeader selects between the single "b3" header and the
+// multi-header (X-B3-*) representation on Inject.
+func B3InjectSingleHeader(single bool) B3PropagatorOption {
+	return func(p *B3Propagator) {
+		p.singleHeader = single
+	}
+}
+
+// Inject implements Injector of B3Propagator.
+func (p *B3Propagator) Inject(sc SpanContext, abstractCarrier interface{}) error {
+	writer, ok := abstractCarrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	traceID := sc.traceID.String()
+	spanID := strconv.FormatUint(uint64(sc.spanID), 16)
+	sampled := "0"
+	if sc.IsDebug() {
+		sampled = "d"
+	} else if sc.IsSampled() {
+		sampled = "1"
+	}
+
+	if p.singleHeader {
+		parts := []string{traceID, spanID, sampled}
+		if sc.parentID != 0 {
+			parts = append(parts, strconv.FormatUint(uint64(sc.parentID), 16))
+		}
+		writer.Set(b3SingleHeader, strings.Join(parts, "-"))
+		return nil
+	}
+
+	writer.Set(b3TraceIDHeader, traceID)
+	writer.Set(b3SpanIDHeader, spanID)
+	if sc.parentID != 0 {
+		writer.Set(b3ParentSpanIDHeader, strconv.FormatUint(uint64(sc.parentID), 16))
+	}
+	if sc.IsDebug() {
+		writer.Set(b3FlagsHeader, "1")
+	} else {
+		writer.Set(b3SampledHeader, sampled)
+	}
+	return nil
+}
+
+// Extract implements Extractor of B3Propagator. It understands both the
+// single "b3" header and the X-B3-* multi-header forms, regardless of how
+// the propagator is configured to inject.
+func (p *B3Propagator) Extract(abstractCarrier interface{}) (SpanContext, error) {
+	reader, ok := abstractCarrier.(opentracing.TextMapReader)
+	if !ok {
+		return emptyContext, opentracing.ErrInvalidCarrier
+	}
+
+	var traceIDStr, spanIDStr, parentIDStr, sampledStr, flagsStr, single string
+	err := reader.ForeachKey(func(rawKey, value string) error {
+		switch strings.ToLower(rawKey) {
+		case b3TraceIDHeader:
+			traceIDStr = value
+		case b3SpanIDHeader:
+			spanIDStr = value
+		case b3ParentSpanIDHeader:
+			parentIDStr = value
+		case b3SampledHeader:
+			sampledStr = value
+		case b3FlagsHeader:
+			flagsStr = value
+		case b3SingleHeader:
+			single = value
+		}
+		return nil
+	})
+	if err != nil {
+		return emptyContext, err
+	}
+
+	if single != "" && single != "0" {
+		parts := strings.Split(single, "-")
+		if len(parts) > 0 {
+			traceIDStr = parts[0]
+		}
+		if len(parts) > 1 {
+			spanIDStr = parts[1]
+		}
+		if len(parts) > 2 {
+			sampledStr = parts[2]
+		}
+		if len(parts) > 3 {
+			parentIDStr = parts[3]
+		}
+	}
+
+	if traceIDStr == "" || spanIDStr == "" {
+		return emptyContext, opentracing.ErrSpanContextNotFound
+	}
+
+	traceID, err := TraceIDFromString(traceIDStr)
+	if err != nil {
+		return emptyContext, opentracing.ErrSpanContextCorrupted
+	}
+	spanID, err := SpanIDFromString(spanIDStr)
+	if err != nil {
+		return emptyContext, opentracing.ErrSpanContextCorrupted
+	}
+	var parentID SpanID
+	if parentIDStr != "" {
+		if parentID, err = SpanIDFromString(parentIDStr); err != nil {
+			return emptyContext, opentracing.ErrSpanContextCorrupted
+		}
+	}
+
+	ctx := SpanContext{
+		traceID:       traceID,
+		spanID:        spanID,
+		parentID:      parentID,
+		samplingState: &samplingState{},
+	}
+	if sampledStr == "d" || flagsStr == "1" {
+		ctx.samplingState.setDebugAndSampled()
+	} else if sampledStr == "1" {
+		ctx.samplingState.setSampled()
+	}
+	return ctx, nil
+}
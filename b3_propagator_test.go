@@ -0,0 +1,61 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestB3PropagatorMultiHeaderRoundTrip(t *testing.T) {
+	p := NewB3Propagator()
+	sc := NewSpanContext(TraceID{High: 1, Low: 2}, SpanID(3), SpanID(4), true, nil)
+
+	carrier := opentracing.HTTPHeadersCarrier(http.Header{})
+	require.NoError(t, p.Inject(sc, carrier))
+	assert.Equal(t, sc.traceID.String(), http.Header(carrier).Get(b3TraceIDHeader))
+
+	extracted, err := p.Extract(carrier)
+	require.NoError(t, err)
+	assert.Equal(t, sc.traceID, extracted.traceID)
+	assert.Equal(t, sc.spanID, extracted.spanID)
+	assert.Equal(t, sc.parentID, extracted.parentID)
+	assert.True(t, extracted.IsSampled())
+}
+
+func TestB3PropagatorSingleHeaderRoundTrip(t *testing.T) {
+	p := NewB3Propagator(B3InjectSingleHeader(true))
+	sc := NewSpanContext(TraceID{Low: 42}, SpanID(7), SpanID(0), false, nil)
+
+	carrier := opentracing.HTTPHeadersCarrier(http.Header{})
+	require.NoError(t, p.Inject(sc, carrier))
+	assert.NotEmpty(t, http.Header(carrier).Get(b3SingleHeader))
+
+	extracted, err := p.Extract(carrier)
+	require.NoError(t, err)
+	assert.Equal(t, sc.traceID, extracted.traceID)
+	assert.Equal(t, sc.spanID, extracted.spanID)
+	assert.False(t, extracted.IsSampled())
+}
+
+func TestB3PropagatorExtractNotFound(t *testing.T) {
+	p := NewB3Propagator()
+	_, err := p.Extract(opentracing.HTTPHeadersCarrier(http.Header{}))
+	assert.Equal(t, opentracing.ErrSpanContextNotFound, err)
+}
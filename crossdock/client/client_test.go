@@ -15,9 +15,12 @@
 package client
 
 import (
+	"context"
+	"io"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/crossdock/crossdock-go"
 	"github.com/stretchr/testify/assert"
@@ -30,6 +33,26 @@ import (
 	jlog "github.com/forging2012/jaeger-client-go/log"
 )
 
+// closeTracer is the context-bounded companion to `defer tCloser.Close()`:
+// it gives the tracer's reporter and any in-flight collector RPC a fixed
+// grace period to flush before giving up, the same budget a Kubernetes
+// SIGTERM handler would get.
+func closeTracer(t *testing.T, tCloser io.Closer) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if closer, ok := tCloser.(interface {
+		CloseWithContext(context.Context) error
+	}); ok {
+		if err := closer.CloseWithContext(ctx); err != nil {
+			t.Logf("tracer did not close within the grace period: %v", err)
+		}
+		return
+	}
+	if err := tCloser.Close(); err != nil {
+		t.Logf("error closing tracer: %v", err)
+	}
+}
+
 func TestCrossdock(t *testing.T) {
 	log.Enabled = false // enable when debugging tests
 	log.Printf("Starting crossdock test")
@@ -45,7 +68,7 @@ func TestCrossdock(t *testing.T) {
 		"crossdock",
 		jaeger.NewConstSampler(false),
 		reporter)
-	defer tCloser.Close()
+	defer closeTracer(t, tCloser)
 
 	s := &server.Server{
 		HostPortHTTP: "127.0.0.1:0",
@@ -74,9 +97,13 @@ func TestCrossdock(t *testing.T) {
 		{
 			name: behaviorTrace,
 			axes: map[string][]string{
-				server1NameParam:      {common.DefaultTracerServiceName},
-				sampledParam:          {"true", "false"},
-				server2NameParam:      {common.DefaultTracerServiceName},
+				server1NameParam: {common.DefaultTracerServiceName},
+				sampledParam:     {"true", "false"},
+				server2NameParam: {common.DefaultTracerServiceName},
+				// KNOWN GAP, not yet fixed: transportGRPC is excluded here
+				// because transport2transport has no mapping for it (see the
+				// comment in trace.go), so gRPC is not exercised by crossdock.
+				// This is a follow-up, not a completed deliverable.
 				server2TransportParam: {transportHTTP, transportDummy},
 				server3NameParam:      {common.DefaultTracerServiceName},
 				server3TransportParam: {transportHTTP},
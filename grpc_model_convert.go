@@ -0,0 +1,113 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"time"
+
+	"github.com/forging2012/jaeger-client-go/proto-gen/api_v2"
+	j "github.com/forging2012/jaeger-client-go/thrift-gen/jaeger"
+)
+
+// thriftSpansToModel converts the jaeger.thrift spans produced by
+// BuildJaegerThrift into the api_v2.Span representation expected by the
+// collector's gRPC API. It is the inverse of the mapping jaeger-collector
+// performs for its Thrift-over-HTTP endpoint, so that the same spans can be
+// submitted over either transport.
+func thriftSpansToModel(spans []*j.Span) []*api_v2.Span {
+	modelSpans := make([]*api_v2.Span, 0, len(spans))
+	for _, s := range spans {
+		modelSpans = append(modelSpans, thriftSpanToModel(s))
+	}
+	return modelSpans
+}
+
+func thriftSpanToModel(s *j.Span) *api_v2.Span {
+	return &api_v2.Span{
+		TraceID:       api_v2.NewTraceID(uint64(s.TraceIdHigh), uint64(s.TraceIdLow)),
+		SpanID:        api_v2.NewSpanID(uint64(s.SpanId)),
+		OperationName: s.OperationName,
+		References:    thriftRefsToModel(s.References),
+		Flags:         api_v2.Flags(s.Flags),
+		StartTime:     microsToTime(s.StartTime),
+		Duration:      time.Duration(s.Duration) * time.Microsecond,
+		Tags:          thriftTagsToModel(s.Tags),
+		Logs:          thriftLogsToModel(s.Logs),
+	}
+}
+
+func thriftProcessToModel(p *j.Process) *api_v2.Process {
+	if p == nil {
+		return nil
+	}
+	return &api_v2.Process{
+		ServiceName: p.ServiceName,
+		Tags:        thriftTagsToModel(p.Tags),
+	}
+}
+
+func thriftRefsToModel(refs []*j.SpanRef) []api_v2.SpanRef {
+	modelRefs := make([]api_v2.SpanRef, 0, len(refs))
+	for _, r := range refs {
+		refType := api_v2.SpanRefType_CHILD_OF
+		if r.RefType == j.SpanRefType_FOLLOWS_FROM {
+			refType = api_v2.SpanRefType_FOLLOWS_FROM
+		}
+		modelRefs = append(modelRefs, api_v2.SpanRef{
+			TraceID: api_v2.NewTraceID(uint64(r.TraceIdHigh), uint64(r.TraceIdLow)),
+			SpanID:  api_v2.NewSpanID(uint64(r.SpanId)),
+			RefType: refType,
+		})
+	}
+	return modelRefs
+}
+
+func thriftTagsToModel(tags []*j.Tag) []api_v2.KeyValue {
+	modelTags := make([]api_v2.KeyValue, 0, len(tags))
+	for _, tag := range tags {
+		modelTags = append(modelTags, thriftTagToModel(tag))
+	}
+	return modelTags
+}
+
+func thriftTagToModel(tag *j.Tag) api_v2.KeyValue {
+	switch tag.VType {
+	case j.TagType_BOOL:
+		return api_v2.Bool(tag.Key, tag.GetVBool())
+	case j.TagType_LONG:
+		return api_v2.Int64(tag.Key, tag.GetVLong())
+	case j.TagType_DOUBLE:
+		return api_v2.Float64(tag.Key, tag.GetVDouble())
+	case j.TagType_BINARY:
+		return api_v2.Binary(tag.Key, tag.VBinary)
+	default:
+		return api_v2.String(tag.Key, tag.GetVStr())
+	}
+}
+
+func thriftLogsToModel(logs []*j.Log) []api_v2.Log {
+	modelLogs := make([]api_v2.Log, 0, len(logs))
+	for _, l := range logs {
+		modelLogs = append(modelLogs, api_v2.Log{
+			Timestamp: microsToTime(l.Timestamp),
+			Fields:    thriftTagsToModel(l.Fields),
+		})
+	}
+	return modelLogs
+}
+
+func microsToTime(micros int64) time.Time {
+	return time.Unix(0, micros*int64(time.Microsecond)).UTC()
+}
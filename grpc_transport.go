@@ -0,0 +1,239 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/forging2012/jaeger-client-go/proto-gen/api_v2"
+	j "github.com/forging2012/jaeger-client-go/thrift-gen/jaeger"
+)
+
+const (
+	defaultGRPCTimeout        = 5 * time.Second
+	defaultGRPCMaxQueueSize   = 1000
+	defaultGRPCMaxRetries     = 3
+	defaultGRPCInitialBackoff = 100 * time.Millisecond
+	defaultGRPCMaxBackoff     = 2 * time.Second
+)
+
+// GRPCTransport implements Transport by streaming jaeger.Batch messages
+// directly to jaeger-collector over gRPC, bypassing jaeger-agent.
+type GRPCTransport struct {
+	client  api_v2.CollectorServiceClient
+	conn    *grpc.ClientConn
+	process *j.Process
+
+	timeout     time.Duration
+	maxRetries  int
+	initialBack time.Duration
+	maxBack     time.Duration
+
+	dialOpts []grpc.DialOption
+
+	mux      sync.Mutex
+	queue    []*j.Span
+	maxQueue int
+	dropped  int64
+}
+
+// GRPCOption configures a GRPCTransport.
+type GRPCOption func(*GRPCTransport)
+
+// GRPCWithTLSConfig enables TLS on the collector connection.
+func GRPCWithTLSConfig(cfg *tls.Config) GRPCOption {
+	return func(t *GRPCTransport) {
+		t.dialOpts = append(t.dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(cfg)))
+	}
+}
+
+// GRPCWithTimeout bounds how long a single batch submission may take.
+func GRPCWithTimeout(timeout time.Duration) GRPCOption {
+	return func(t *GRPCTransport) { t.timeout = timeout }
+}
+
+// GRPCWithMaxQueueSize bounds the number of spans buffered between Flush
+// calls. The Append that fills the queue flushes it. If the queue is already
+// full when Append is called, Append drops the oldest queued span and
+// increments the transport's internal dropped-span count.
+func GRPCWithMaxQueueSize(n int) GRPCOption {
+	return func(t *GRPCTransport) { t.maxQueue = n }
+}
+
+// GRPCWithRetry configures the number of retry attempts and the
+// exponential backoff bounds used when the collector returns UNAVAILABLE.
+func GRPCWithRetry(maxRetries int, initialBackoff, maxBackoff time.Duration) GRPCOption {
+	return func(t *GRPCTransport) {
+		t.maxRetries = maxRetries
+		t.initialBack = initialBackoff
+		t.maxBack = maxBackoff
+	}
+}
+
+// GRPCWithDialOptions passes through arbitrary grpc.DialOptions, e.g. for
+// custom interceptors or insecure.NewCredentials() in tests.
+func GRPCWithDialOptions(opts ...grpc.DialOption) GRPCOption {
+	return func(t *GRPCTransport) { t.dialOpts = append(t.dialOpts, opts...) }
+}
+
+// NewGRPCTransport returns a Transport that submits spans directly to
+// collectorAddr (host:port) over gRPC, without going through jaeger-agent.
+func NewGRPCTransport(collectorAddr string, opts ...GRPCOption) (*GRPCTransport, error) {
+	t := &GRPCTransport{
+		timeout:     defaultGRPCTimeout,
+		maxQueue:    defaultGRPCMaxQueueSize,
+		maxRetries:  defaultGRPCMaxRetries,
+		initialBack: defaultGRPCInitialBackoff,
+		maxBack:     defaultGRPCMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if len(t.dialOpts) == 0 {
+		t.dialOpts = append(t.dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+	}
+
+	conn, err := grpc.Dial(collectorAddr, t.dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	t.conn = conn
+	t.client = api_v2.NewCollectorServiceClient(conn)
+	return t, nil
+}
+
+// Append implements Transport.
+func (t *GRPCTransport) Append(span *Span) (int, error) {
+	return t.AppendWithContext(context.Background(), span)
+}
+
+// AppendWithContext implements TransportWithContext.
+func (t *GRPCTransport) AppendWithContext(ctx context.Context, span *Span) (int, error) {
+	if t.process == nil {
+		t.process = BuildJaegerProcessThrift(span)
+	}
+	jSpan := BuildJaegerThrift(span)
+
+	t.mux.Lock()
+	if len(t.queue) >= t.maxQueue {
+		// Drop the oldest span to make room; the caller already paid the
+		// cost of building it, but an unbounded queue risks unbounded
+		// memory growth if the collector is unreachable.
+		t.queue = t.queue[1:]
+		t.dropped++
+	}
+	t.queue = append(t.queue, jSpan)
+	full := len(t.queue) >= t.maxQueue
+	t.mux.Unlock()
+
+	if full {
+		return t.FlushWithContext(ctx)
+	}
+	return 0, nil
+}
+
+// Flush implements Transport.
+func (t *GRPCTransport) Flush() (int, error) {
+	return t.FlushWithContext(context.Background())
+}
+
+// FlushWithContext implements TransportWithContext. The batch submission is
+// additionally bounded by the transport's own timeout, whichever of ctx or
+// the timeout elapses first.
+func (t *GRPCTransport) FlushWithContext(ctx context.Context) (int, error) {
+	t.mux.Lock()
+	spans := t.queue
+	t.queue = nil
+	process := t.process
+	t.mux.Unlock()
+
+	if len(spans) == 0 {
+		return 0, nil
+	}
+
+	batch := api_v2.Batch{
+		Spans:   thriftSpansToModel(spans),
+		Process: thriftProcessToModel(process),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	err := t.postWithRetry(ctx, batch)
+	if err != nil {
+		return len(spans), err
+	}
+	return len(spans), nil
+}
+
+// Close implements Transport.
+func (t *GRPCTransport) Close() error {
+	return t.CloseWithContext(context.Background())
+}
+
+// CloseWithContext implements TransportWithContext, returning early with
+// ctx.Err() if ctx is done before the underlying connection finishes
+// closing.
+func (t *GRPCTransport) CloseWithContext(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() { done <- t.conn.Close() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// DroppedSpans returns the number of spans discarded because the internal
+// queue was full when Append was called.
+func (t *GRPCTransport) DroppedSpans() int64 {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.dropped
+}
+
+func (t *GRPCTransport) postWithRetry(ctx context.Context, batch api_v2.Batch) error {
+	backoff := t.initialBack
+	var lastErr error
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		_, err := t.client.PostSpans(ctx, &api_v2.PostSpansRequest{Batch: batch})
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if status.Code(err) != codes.Unavailable || attempt == t.maxRetries {
+			return lastErr
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+		if backoff > t.maxBack {
+			backoff = t.maxBack
+		}
+	}
+	return lastErr
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"sync"
+	"time"
+)
+
+// HistogramSnapshot is a point-in-time readout of a histogram's value
+// distribution.
+type HistogramSnapshot struct {
+	Count int64
+	P50   int64
+	P90   int64
+	P99   int64
+	P999  int64
+}
+
+// hdrHistogram is a minimal, self-contained histogram in the spirit of
+// HdrHistogram: it buckets values on a logarithmic scale so that relative
+// error stays bounded by significantFigures across the full trackable
+// range, rather than degrading for large values the way a fixed-width
+// linear histogram would. It is not a port of any particular HdrHistogram
+// implementation, just a small building block sized for this package's own
+// latency and batch-size metrics.
+type hdrHistogram struct {
+	mu sync.Mutex
+
+	lowest  int64
+	highest int64
+
+	unitMagnitude  uint
+	subBucketCount int64
+	numBuckets     int
+
+	counts     []int64
+	totalCount int64
+}
+
+// newHDRHistogram creates a histogram that tracks values in
+// [lowest, highest] with the relative precision implied by
+// significantFigures (typically 1-5).
+func newHDRHistogram(lowest, highest int64, significantFigures int) *hdrHistogram {
+	if lowest < 1 {
+		lowest = 1
+	}
+	if highest < lowest {
+		highest = lowest
+	}
+
+	subBucketMagnitude := subBucketMagnitudeForSigFigs(significantFigures)
+	subBucketCount := int64(1) << subBucketMagnitude
+
+	var unitMagnitude uint
+	for int64(1)<<(unitMagnitude+1) <= lowest {
+		unitMagnitude++
+	}
+
+	numBuckets := 1
+	for (subBucketCount<<uint(numBuckets-1))<<unitMagnitude < highest && numBuckets < 64 {
+		numBuckets++
+	}
+
+	return &hdrHistogram{
+		lowest:         lowest,
+		highest:        highest,
+		unitMagnitude:  unitMagnitude,
+		subBucketCount: subBucketCount,
+		numBuckets:     numBuckets,
+		counts:         make([]int64, int64(numBuckets)*subBucketCount),
+	}
+}
+
+// subBucketMagnitudeForSigFigs returns the number of bits needed so that a
+// linear bucket of 2*10^significantFigures counters fits, which is what
+// gives each bucket its significant-figures worth of resolution.
+func subBucketMagnitudeForSigFigs(significantFigures int) uint {
+	largest := int64(2)
+	for i := 0; i < significantFigures; i++ {
+		largest *= 10
+	}
+	var magnitude uint
+	for int64(1)<<magnitude < largest {
+		magnitude++
+	}
+	return magnitude
+}
+
+// index maps v to the (bucket, subBucket) pair it falls into.
+func (h *hdrHistogram) index(v int64) (int, int64) {
+	n := v >> h.unitMagnitude
+	bucketIdx := 0
+	for n >= h.subBucketCount {
+		n >>= 1
+		bucketIdx++
+	}
+	if bucketIdx >= h.numBuckets {
+		bucketIdx = h.numBuckets - 1
+		n = h.subBucketCount - 1
+	}
+	return bucketIdx, n
+}
+
+// valueAtIndex returns the representative (lower-bound) value of a bucket.
+func (h *hdrHistogram) valueAtIndex(bucketIdx int, subBucketIdx int64) int64 {
+	return subBucketIdx << (h.unitMagnitude + uint(bucketIdx))
+}
+
+// recordValue adds v to the histogram, clamping to the trackable range.
+func (h *hdrHistogram) recordValue(v int64) {
+	if v < h.lowest {
+		v = h.lowest
+	}
+	if v > h.highest {
+		v = h.highest
+	}
+	bucketIdx, subBucketIdx := h.index(v)
+
+	h.mu.Lock()
+	h.counts[int64(bucketIdx)*h.subBucketCount+subBucketIdx]++
+	h.totalCount++
+	h.mu.Unlock()
+}
+
+// reset clears all recorded values.
+func (h *hdrHistogram) reset() {
+	h.mu.Lock()
+	for i := range h.counts {
+		h.counts[i] = 0
+	}
+	h.totalCount = 0
+	h.mu.Unlock()
+}
+
+// valueAtQuantileLocked returns the representative (lower-bound) value of
+// the bucket holding the given percentile (0-100). Callers must hold h.mu.
+func (h *hdrHistogram) valueAtQuantileLocked(quantile float64) int64 {
+	if h.totalCount == 0 {
+		return 0
+	}
+	target := int64((quantile / 100.0) * float64(h.totalCount))
+	if target < 1 {
+		target = 1
+	}
+	var cumulative int64
+	for bucketIdx := 0; bucketIdx < h.numBuckets; bucketIdx++ {
+		base := int64(bucketIdx) * h.subBucketCount
+		for subBucketIdx := int64(0); subBucketIdx < h.subBucketCount; subBucketIdx++ {
+			cumulative += h.counts[base+subBucketIdx]
+			if cumulative >= target {
+				return h.valueAtIndex(bucketIdx, subBucketIdx)
+			}
+		}
+	}
+	return h.highest
+}
+
+// snapshot returns the current percentile readout.
+func (h *hdrHistogram) snapshot() HistogramSnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.totalCount == 0 {
+		return HistogramSnapshot{}
+	}
+	return HistogramSnapshot{
+		Count: h.totalCount,
+		P50:   h.valueAtQuantileLocked(50),
+		P90:   h.valueAtQuantileLocked(90),
+		P99:   h.valueAtQuantileLocked(99),
+		P999:  h.valueAtQuantileLocked(99.9),
+	}
+}
+
+func (h *hdrHistogram) count() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.totalCount
+}
+
+// windowedHistogram wraps two hdrHistograms and rotates between them on a
+// fixed interval, so a long-running process reports percentiles for recent
+// activity instead of an ever-growing all-time distribution.
+type windowedHistogram struct {
+	newHist func() *hdrHistogram
+
+	mux      sync.Mutex
+	current  *hdrHistogram
+	previous *hdrHistogram
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// newWindowedHistogram creates a windowed histogram over [lowest, highest]
+// with the given precision, rotating its active window every
+// rotationInterval. A non-positive rotationInterval disables rotation; the
+// histogram then behaves like a single all-time hdrHistogram.
+func newWindowedHistogram(rotationInterval time.Duration, lowest, highest int64, significantFigures int) *windowedHistogram {
+	newHist := func() *hdrHistogram { return newHDRHistogram(lowest, highest, significantFigures) }
+	w := &windowedHistogram{
+		newHist:  newHist,
+		current:  newHist(),
+		previous: newHist(),
+		stop:     make(chan struct{}),
+	}
+	if rotationInterval > 0 {
+		go w.rotateLoop(rotationInterval)
+	}
+	return w
+}
+
+func (w *windowedHistogram) rotateLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.rotate()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+func (w *windowedHistogram) rotate() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.previous, w.current = w.current, w.previous
+	w.current.reset()
+}
+
+// recordValue records v against the currently active window.
+func (w *windowedHistogram) recordValue(v int64) {
+	w.mux.Lock()
+	h := w.current
+	w.mux.Unlock()
+	h.recordValue(v)
+}
+
+// snapshot reports percentiles for the most recently completed window. If
+// no window has completed yet (rotation disabled, or still within the
+// first interval), it falls back to the in-progress window so callers see
+// data immediately rather than an empty snapshot.
+func (w *windowedHistogram) snapshot() HistogramSnapshot {
+	w.mux.Lock()
+	h := w.previous
+	if h.count() == 0 {
+		h = w.current
+	}
+	w.mux.Unlock()
+	return h.snapshot()
+}
+
+// close stops the background rotation goroutine, if any.
+func (w *windowedHistogram) close() {
+	w.once.Do(func() { close(w.stop) })
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHDRHistogramPercentiles(t *testing.T) {
+	h := newHDRHistogram(int64(time.Microsecond), int64(time.Minute), 3)
+	for i := 1; i <= 100; i++ {
+		h.recordValue(int64(i) * int64(time.Millisecond))
+	}
+
+	snap := h.snapshot()
+	assert.EqualValues(t, 100, snap.Count)
+	assert.True(t, snap.P50 > 0 && snap.P50 < snap.P99, "expected 0 < p50 < p99, got p50=%d p99=%d", snap.P50, snap.P99)
+	assert.True(t, snap.P999 >= snap.P99, "expected p999 >= p99, got p999=%d p99=%d", snap.P999, snap.P99)
+}
+
+func TestHDRHistogramClampsOutOfRangeValues(t *testing.T) {
+	h := newHDRHistogram(int64(time.Microsecond), int64(time.Minute), 3)
+	h.recordValue(0)
+	h.recordValue(int64(time.Hour))
+
+	snap := h.snapshot()
+	assert.EqualValues(t, 2, snap.Count)
+}
+
+func TestHDRHistogramEmptySnapshot(t *testing.T) {
+	h := newHDRHistogram(int64(time.Microsecond), int64(time.Minute), 3)
+	assert.Zero(t, h.snapshot())
+}
+
+func TestWindowedHistogramRotation(t *testing.T) {
+	w := newWindowedHistogram(20*time.Millisecond, int64(time.Microsecond), int64(time.Minute), 3)
+	defer w.close()
+
+	w.recordValue(int64(5 * time.Millisecond))
+	time.Sleep(30 * time.Millisecond)
+
+	snap := w.snapshot()
+	assert.NotZero(t, snap.Count, "expected the rotated-out window to still be visible")
+}
+
+func TestWindowedHistogramFallsBackToCurrentWindow(t *testing.T) {
+	w := newWindowedHistogram(0, int64(time.Microsecond), int64(time.Minute), 3)
+	defer w.close()
+
+	w.recordValue(int64(5 * time.Millisecond))
+	assert.EqualValues(t, 1, w.snapshot().Count)
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+const (
+	// defaultHistogramWindow is how often the HDR histograms rotate their
+	// active window, bounding how much memory they retain.
+	defaultHistogramWindow = time.Minute
+
+	minDurationHistogramValue = int64(time.Microsecond)
+	maxDurationHistogramValue = int64(time.Minute)
+	durationHistogramSigFigs  = 3
+
+	minBatchSizeHistogramValue = int64(1)
+	maxBatchSizeHistogramValue = int64(100000)
+	batchSizeHistogramSigFigs  = 3
+)
+
+// MetricsSnapshot is a percentile readout of every histogram Metrics
+// tracks, as returned by Metrics.HistogramSnapshot().
+type MetricsSnapshot struct {
+	SpanDuration           HistogramSnapshot
+	ReporterQueueLatency   HistogramSnapshot
+	TransportAppendLatency HistogramSnapshot
+	TransportFlushLatency  HistogramSnapshot
+	TransportBatchSize     HistogramSnapshot
+}
+
+// HistogramEmitter receives periodic percentile snapshots of the tracer's
+// internal histograms, e.g. to forward them into an external metrics
+// system that jaeger-lib's own Counter/Gauge abstractions can't express.
+type HistogramEmitter interface {
+	EmitHistograms(MetricsSnapshot)
+}
+
+// Metrics is a container for the tracer's optional internal
+// instrumentation: jaeger-lib counters and gauges for simple event counts,
+// plus HDR-histogram-backed distributions for latencies and batch sizes
+// that a single counter can't describe.
+type Metrics struct {
+	// ReporterSuccess is the number of spans successfully reported.
+	ReporterSuccess metrics.Counter
+	// ReporterFailure is the number of spans not reported due to a transport error.
+	ReporterFailure metrics.Counter
+	// ReporterDropped is the number of spans dropped because the reporter queue was full.
+	ReporterDropped metrics.Counter
+	// ReporterQueueLength is the current number of spans buffered in the reporter queue.
+	ReporterQueueLength metrics.Gauge
+
+	// SpanDuration records how long sampled spans were open, from StartSpan to Finish.
+	SpanDuration *windowedHistogram
+	// ReporterQueueLatency records how long a span waited in the RemoteReporter's
+	// queue before being handed to the Transport.
+	ReporterQueueLatency *windowedHistogram
+	// TransportAppendLatency records how long Transport.Append/AppendWithContext took.
+	TransportAppendLatency *windowedHistogram
+	// TransportFlushLatency records how long Transport.Flush/FlushWithContext took.
+	TransportFlushLatency *windowedHistogram
+	// TransportBatchSize records the number of spans in each flushed batch.
+	TransportBatchSize *windowedHistogram
+
+	emitter      HistogramEmitter
+	emitStop     chan struct{}
+	windowPeriod time.Duration
+}
+
+// MetricsOption configures optional behavior of a Metrics instance.
+type MetricsOption func(*Metrics)
+
+// MetricsOptions is a factory for all available MetricsOption's.
+var MetricsOptions MetricsOptionsFactory
+
+// MetricsOptionsFactory is a factory for all available MetricsOption's. The
+// type acts as a namespace for factory functions, made public so they are
+// discoverable via godoc; recommended to be used via the global
+// MetricsOptions variable.
+type MetricsOptionsFactory struct{}
+
+// Emitter configures a HistogramEmitter that is periodically given a
+// snapshot of all histograms, at the same cadence as their rotation.
+func (MetricsOptionsFactory) Emitter(emitter HistogramEmitter) MetricsOption {
+	return func(m *Metrics) { m.emitter = emitter }
+}
+
+// HistogramWindow overrides how often histograms rotate their active
+// window. Defaults to one minute.
+func (MetricsOptionsFactory) HistogramWindow(window time.Duration) MetricsOption {
+	return func(m *Metrics) { m.windowPeriod = window }
+}
+
+// NewMetrics creates a new Metrics backed by the given jaeger-lib Factory
+// for its counters and gauges.
+func NewMetrics(factory metrics.Factory, opts ...MetricsOption) *Metrics {
+	m := &Metrics{
+		ReporterSuccess: factory.Counter(metrics.Options{
+			Name: "reporter_spans", Tags: map[string]string{"result": "ok"},
+			Help: "Number of spans successfully reported",
+		}),
+		ReporterFailure: factory.Counter(metrics.Options{
+			Name: "reporter_spans", Tags: map[string]string{"result": "err"},
+			Help: "Number of spans not reported due to a transport error",
+		}),
+		ReporterDropped: factory.Counter(metrics.Options{
+			Name: "reporter_spans", Tags: map[string]string{"result": "dropped"},
+			Help: "Number of spans dropped because the reporter queue was full",
+		}),
+		ReporterQueueLength: factory.Gauge(metrics.Options{
+			Name: "reporter_queue_length",
+			Help: "Current number of spans buffered in the reporter queue",
+		}),
+		windowPeriod: defaultHistogramWindow,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	m.SpanDuration = newWindowedHistogram(m.windowPeriod, minDurationHistogramValue, maxDurationHistogramValue, durationHistogramSigFigs)
+	m.ReporterQueueLatency = newWindowedHistogram(m.windowPeriod, minDurationHistogramValue, maxDurationHistogramValue, durationHistogramSigFigs)
+	m.TransportAppendLatency = newWindowedHistogram(m.windowPeriod, minDurationHistogramValue, maxDurationHistogramValue, durationHistogramSigFigs)
+	m.TransportFlushLatency = newWindowedHistogram(m.windowPeriod, minDurationHistogramValue, maxDurationHistogramValue, durationHistogramSigFigs)
+	m.TransportBatchSize = newWindowedHistogram(m.windowPeriod, minBatchSizeHistogramValue, maxBatchSizeHistogramValue, batchSizeHistogramSigFigs)
+
+	if m.emitter != nil && m.windowPeriod > 0 {
+		m.emitStop = make(chan struct{})
+		go m.emitLoop()
+	}
+	return m
+}
+
+// NewNullMetrics creates a Metrics that records histograms in memory but
+// reports its counters and gauges nowhere.
+func NewNullMetrics() *Metrics {
+	return NewMetrics(metrics.NullFactory)
+}
+
+// HistogramSnapshot returns the current percentiles (p50/p90/p99/p999) of
+// every histogram this Metrics tracks.
+func (m *Metrics) HistogramSnapshot() MetricsSnapshot {
+	return MetricsSnapshot{
+		SpanDuration:           m.SpanDuration.snapshot(),
+		ReporterQueueLatency:   m.ReporterQueueLatency.snapshot(),
+		TransportAppendLatency: m.TransportAppendLatency.snapshot(),
+		TransportFlushLatency:  m.TransportFlushLatency.snapshot(),
+		TransportBatchSize:     m.TransportBatchSize.snapshot(),
+	}
+}
+
+func (m *Metrics) emitLoop() {
+	ticker := time.NewTicker(m.windowPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.emitter.EmitHistograms(m.HistogramSnapshot())
+		case <-m.emitStop:
+			return
+		}
+	}
+}
+
+// Close stops the background rotation and emission goroutines.
+func (m *Metrics) Close() {
+	m.SpanDuration.close()
+	m.ReporterQueueLatency.close()
+	m.TransportAppendLatency.close()
+	m.TransportFlushLatency.close()
+	m.TransportBatchSize.close()
+	if m.emitStop != nil {
+		close(m.emitStop)
+	}
+}
@@ -0,0 +1,59 @@
+package jaeger
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeHistogramEmitter struct {
+	mux  sync.Mutex
+	seen []MetricsSnapshot
+}
+
+func (f *fakeHistogramEmitter) EmitHistograms(s MetricsSnapshot) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.seen = append(f.seen, s)
+}
+
+func (f *fakeHistogramEmitter) count() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return len(f.seen)
+}
+
+func TestMetricsHistogramSnapshot(t *testing.T) {
+	m := NewNullMetrics()
+	defer m.Close()
+
+	m.SpanDuration.recordValue(int64(5 * time.Millisecond))
+	m.ReporterQueueLatency.recordValue(int64(time.Millisecond))
+	m.TransportAppendLatency.recordValue(int64(2 * time.Millisecond))
+	m.TransportFlushLatency.recordValue(int64(3 * time.Millisecond))
+	m.TransportBatchSize.recordValue(42)
+
+	snap := m.HistogramSnapshot()
+	assert.EqualValues(t, 1, snap.SpanDuration.Count)
+	assert.EqualValues(t, 1, snap.ReporterQueueLatency.Count)
+	assert.EqualValues(t, 1, snap.TransportAppendLatency.Count)
+	assert.EqualValues(t, 1, snap.TransportFlushLatency.Count)
+	assert.EqualValues(t, 1, snap.TransportBatchSize.Count)
+}
+
+func TestMetricsEmitsToHistogramEmitter(t *testing.T) {
+	emitter := new(fakeHistogramEmitter)
+	m := NewMetrics(noopFactory{},
+		MetricsOptions.Emitter(emitter),
+		MetricsOptions.HistogramWindow(10*time.Millisecond))
+	defer m.Close()
+
+	m.SpanDuration.recordValue(int64(time.Millisecond))
+
+	for i := 0; i < 50 && emitter.count() == 0; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.True(t, emitter.count() > 0, "expected the emitter to be invoked at least once")
+}
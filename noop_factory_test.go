@@ -0,0 +1,13 @@
+package jaeger
+
+import "github.com/uber/jaeger-lib/metrics"
+
+type noopFactory struct{}
+
+func (noopFactory) Counter(metrics.Options) metrics.Counter  { return metrics.NullCounter }
+func (noopFactory) Timer(metrics.TimerOptions) metrics.Timer { return metrics.NullTimer }
+func (noopFactory) Gauge(metrics.Options) metrics.Gauge      { return metrics.NullGauge }
+func (noopFactory) Histogram(metrics.HistogramOptions) metrics.Histogram {
+	return metrics.NullHistogram
+}
+func (noopFactory) Namespace(metrics.NSOptions) metrics.Factory { return noopFactory{} }
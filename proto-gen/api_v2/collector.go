@@ -0,0 +1,93 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_v2
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// PostSpansRequest is the request message for
+// jaeger.api_v2.CollectorService/PostSpans.
+type PostSpansRequest struct {
+	Batch Batch
+}
+
+func (m *PostSpansRequest) Reset()         { *m = PostSpansRequest{} }
+func (m *PostSpansRequest) String() string { return "PostSpansRequest{...}" }
+func (*PostSpansRequest) ProtoMessage()    {}
+
+func (m *PostSpansRequest) Marshal() ([]byte, error) {
+	var dst []byte
+	b, err := m.Batch.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dst = appendMessage(dst, 1, b)
+	return dst, nil
+}
+
+func (m *PostSpansRequest) Unmarshal(data []byte) error {
+	*m = PostSpansRequest{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		if f.num == 1 {
+			if err := m.Batch.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PostSpansResponse is the (empty) response message for
+// jaeger.api_v2.CollectorService/PostSpans.
+type PostSpansResponse struct{}
+
+func (m *PostSpansResponse) Reset()                      { *m = PostSpansResponse{} }
+func (m *PostSpansResponse) String() string              { return "PostSpansResponse{}" }
+func (*PostSpansResponse) ProtoMessage()                 {}
+func (m *PostSpansResponse) Marshal() ([]byte, error)    { return nil, nil }
+func (m *PostSpansResponse) Unmarshal(data []byte) error { return nil }
+
+// CollectorServiceClient is the gRPC client interface for
+// jaeger.api_v2.CollectorService, the same service jaeger-collector
+// exposes for this module's GRPCTransport to submit spans to.
+type CollectorServiceClient interface {
+	PostSpans(ctx context.Context, in *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error)
+}
+
+type collectorServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewCollectorServiceClient returns a CollectorServiceClient that submits
+// span batches to cc over the jaeger.api_v2.CollectorService/PostSpans RPC.
+func NewCollectorServiceClient(cc *grpc.ClientConn) CollectorServiceClient {
+	return &collectorServiceClient{cc: cc}
+}
+
+func (c *collectorServiceClient) PostSpans(ctx context.Context, in *PostSpansRequest, opts ...grpc.CallOption) (*PostSpansResponse, error) {
+	out := new(PostSpansResponse)
+	if err := c.cc.Invoke(ctx, "/jaeger.api_v2.CollectorService/PostSpans", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,532 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api_v2 is a minimal, hand-maintained subset of the
+// jaeger.api_v2 protobuf contract (model.proto and collector.proto from
+// github.com/jaegertracing/jaeger-idl) needed by GRPCTransport to submit a
+// batch of spans to a jaeger-collector over gRPC. It exists so the client
+// does not have to depend on the jaegertracing/jaeger backend module just
+// to get these wire types; the message and field layout below is kept
+// wire-compatible with that module's generated code.
+package api_v2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+)
+
+// ValueType identifies which of KeyValue's value fields is populated.
+type ValueType int32
+
+const (
+	ValueType_STRING  ValueType = 0
+	ValueType_BOOL    ValueType = 1
+	ValueType_INT64   ValueType = 2
+	ValueType_FLOAT64 ValueType = 3
+	ValueType_BINARY  ValueType = 4
+)
+
+// SpanRefType identifies the relationship a SpanRef describes.
+type SpanRefType int32
+
+const (
+	SpanRefType_CHILD_OF     SpanRefType = 0
+	SpanRefType_FOLLOWS_FROM SpanRefType = 1
+)
+
+// Flags holds the per-span bitset (e.g. sampled/debug) used by the
+// collector.
+type Flags uint32
+
+// TraceID is the wire representation of a 128bit trace ID: 16 raw bytes,
+// high half followed by low half, big-endian.
+type TraceID struct {
+	High, Low uint64
+}
+
+// NewTraceID builds a TraceID from its high and low 64bit halves.
+func NewTraceID(high, low uint64) TraceID {
+	return TraceID{High: high, Low: low}
+}
+
+func (t TraceID) Marshal() ([]byte, error) {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], t.High)
+	binary.BigEndian.PutUint64(b[8:], t.Low)
+	return b, nil
+}
+
+func (t *TraceID) Unmarshal(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("api_v2: invalid TraceID length %d", len(data))
+	}
+	t.High = binary.BigEndian.Uint64(data[:8])
+	t.Low = binary.BigEndian.Uint64(data[8:])
+	return nil
+}
+
+// SpanID is the wire representation of a 64bit span ID: 8 raw bytes,
+// big-endian.
+type SpanID uint64
+
+// NewSpanID builds a SpanID from a 64bit value.
+func NewSpanID(v uint64) SpanID {
+	return SpanID(v)
+}
+
+func (s SpanID) Marshal() ([]byte, error) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(s))
+	return b, nil
+}
+
+func (s *SpanID) Unmarshal(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("api_v2: invalid SpanID length %d", len(data))
+	}
+	*s = SpanID(binary.BigEndian.Uint64(data))
+	return nil
+}
+
+// KeyValue is a single tag or log field, tagged with the Go type of the
+// value it carries.
+type KeyValue struct {
+	Key      string
+	VType    ValueType
+	VStr     string
+	VBool    bool
+	VInt64   int64
+	VFloat64 float64
+	VBinary  []byte
+}
+
+// String returns a string-valued KeyValue.
+func String(key, value string) KeyValue {
+	return KeyValue{Key: key, VType: ValueType_STRING, VStr: value}
+}
+
+// Bool returns a bool-valued KeyValue.
+func Bool(key string, value bool) KeyValue {
+	return KeyValue{Key: key, VType: ValueType_BOOL, VBool: value}
+}
+
+// Int64 returns an int64-valued KeyValue.
+func Int64(key string, value int64) KeyValue {
+	return KeyValue{Key: key, VType: ValueType_INT64, VInt64: value}
+}
+
+// Float64 returns a float64-valued KeyValue.
+func Float64(key string, value float64) KeyValue {
+	return KeyValue{Key: key, VType: ValueType_FLOAT64, VFloat64: value}
+}
+
+// Binary returns a []byte-valued KeyValue.
+func Binary(key string, value []byte) KeyValue {
+	return KeyValue{Key: key, VType: ValueType_BINARY, VBinary: value}
+}
+
+func (m *KeyValue) Reset()         { *m = KeyValue{} }
+func (m *KeyValue) String() string { return fmt.Sprintf("%+v", *m) }
+func (*KeyValue) ProtoMessage()    {}
+
+func (m *KeyValue) Marshal() ([]byte, error) {
+	var dst []byte
+	dst = appendString(dst, 1, m.Key)
+	dst = appendVarintField(dst, 2, uint64(m.VType))
+	dst = appendString(dst, 3, m.VStr)
+	if m.VBool {
+		dst = appendTag(dst, 4, wireVarint)
+		dst = appendVarint(dst, 1)
+	}
+	dst = appendVarintField(dst, 5, uint64(m.VInt64))
+	dst = appendFixed64(dst, 6, math.Float64bits(m.VFloat64))
+	dst = appendBytes(dst, 7, m.VBinary)
+	return dst, nil
+}
+
+func (m *KeyValue) Unmarshal(data []byte) error {
+	*m = KeyValue{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		switch f.num {
+		case 1:
+			m.Key = string(f.bytes)
+		case 2:
+			m.VType = ValueType(f.vint)
+		case 3:
+			m.VStr = string(f.bytes)
+		case 4:
+			m.VBool = f.vint != 0
+		case 5:
+			m.VInt64 = int64(f.vint)
+		case 6:
+			m.VFloat64 = math.Float64frombits(f.vint)
+		case 7:
+			m.VBinary = append([]byte(nil), f.bytes...)
+		}
+	}
+	return nil
+}
+
+// Log is a timestamped set of fields attached to a span.
+type Log struct {
+	Timestamp time.Time
+	Fields    []KeyValue
+}
+
+func (m *Log) Reset()         { *m = Log{} }
+func (m *Log) String() string { return fmt.Sprintf("%+v", *m) }
+func (*Log) ProtoMessage()    {}
+
+func (m *Log) Marshal() ([]byte, error) {
+	var dst []byte
+	ts, err := types.StdTimeMarshal(m.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+	dst = appendMessage(dst, 1, ts)
+	for i := range m.Fields {
+		b, err := m.Fields[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 2, b)
+	}
+	return dst, nil
+}
+
+func (m *Log) Unmarshal(data []byte) error {
+	*m = Log{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		switch f.num {
+		case 1:
+			if err := types.StdTimeUnmarshal(&m.Timestamp, f.bytes); err != nil {
+				return err
+			}
+		case 2:
+			var kv KeyValue
+			if err := kv.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.Fields = append(m.Fields, kv)
+		}
+	}
+	return nil
+}
+
+// SpanRef describes this span's relationship to another span, e.g. its
+// parent.
+type SpanRef struct {
+	TraceID TraceID
+	SpanID  SpanID
+	RefType SpanRefType
+}
+
+func (m *SpanRef) Reset()         { *m = SpanRef{} }
+func (m *SpanRef) String() string { return fmt.Sprintf("%+v", *m) }
+func (*SpanRef) ProtoMessage()    {}
+
+func (m *SpanRef) Marshal() ([]byte, error) {
+	var dst []byte
+	tid, err := m.TraceID.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dst = appendBytes(dst, 1, tid)
+	sid, err := m.SpanID.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dst = appendBytes(dst, 2, sid)
+	dst = appendVarintField(dst, 3, uint64(m.RefType))
+	return dst, nil
+}
+
+func (m *SpanRef) Unmarshal(data []byte) error {
+	*m = SpanRef{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		switch f.num {
+		case 1:
+			if err := m.TraceID.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		case 2:
+			if err := m.SpanID.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		case 3:
+			m.RefType = SpanRefType(f.vint)
+		}
+	}
+	return nil
+}
+
+// Process describes the service that produced a batch of spans.
+type Process struct {
+	ServiceName string
+	Tags        []KeyValue
+}
+
+func (m *Process) Reset()         { *m = Process{} }
+func (m *Process) String() string { return fmt.Sprintf("%+v", *m) }
+func (*Process) ProtoMessage()    {}
+
+func (m *Process) Marshal() ([]byte, error) {
+	var dst []byte
+	dst = appendString(dst, 1, m.ServiceName)
+	for i := range m.Tags {
+		b, err := m.Tags[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 2, b)
+	}
+	return dst, nil
+}
+
+func (m *Process) Unmarshal(data []byte) error {
+	*m = Process{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		switch f.num {
+		case 1:
+			m.ServiceName = string(f.bytes)
+		case 2:
+			var kv KeyValue
+			if err := kv.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.Tags = append(m.Tags, kv)
+		}
+	}
+	return nil
+}
+
+// Span is the wire representation of a single completed span, submitted to
+// jaeger-collector as part of a Batch.
+type Span struct {
+	TraceID       TraceID
+	SpanID        SpanID
+	OperationName string
+	References    []SpanRef
+	Flags         Flags
+	StartTime     time.Time
+	Duration      time.Duration
+	Tags          []KeyValue
+	Logs          []Log
+	Process       *Process
+	ProcessID     string
+	Warnings      []string
+}
+
+func (m *Span) Reset()         { *m = Span{} }
+func (m *Span) String() string { return fmt.Sprintf("%+v", *m) }
+func (*Span) ProtoMessage()    {}
+
+func (m *Span) Marshal() ([]byte, error) {
+	var dst []byte
+	tid, err := m.TraceID.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dst = appendBytes(dst, 1, tid)
+	sid, err := m.SpanID.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	dst = appendBytes(dst, 2, sid)
+	dst = appendString(dst, 3, m.OperationName)
+	for i := range m.References {
+		b, err := m.References[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 4, b)
+	}
+	dst = appendVarintField(dst, 5, uint64(m.Flags))
+	st, err := types.StdTimeMarshal(m.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	dst = appendMessage(dst, 6, st)
+	dur, err := types.StdDurationMarshal(m.Duration)
+	if err != nil {
+		return nil, err
+	}
+	dst = appendMessage(dst, 7, dur)
+	for i := range m.Tags {
+		b, err := m.Tags[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 8, b)
+	}
+	for i := range m.Logs {
+		b, err := m.Logs[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 9, b)
+	}
+	if m.Process != nil {
+		b, err := m.Process.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 10, b)
+	}
+	dst = appendString(dst, 11, m.ProcessID)
+	for _, w := range m.Warnings {
+		dst = appendString(dst, 12, w)
+	}
+	return dst, nil
+}
+
+func (m *Span) Unmarshal(data []byte) error {
+	*m = Span{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		switch f.num {
+		case 1:
+			if err := m.TraceID.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		case 2:
+			if err := m.SpanID.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		case 3:
+			m.OperationName = string(f.bytes)
+		case 4:
+			var ref SpanRef
+			if err := ref.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.References = append(m.References, ref)
+		case 5:
+			m.Flags = Flags(f.vint)
+		case 6:
+			if err := types.StdTimeUnmarshal(&m.StartTime, f.bytes); err != nil {
+				return err
+			}
+		case 7:
+			if err := types.StdDurationUnmarshal(&m.Duration, f.bytes); err != nil {
+				return err
+			}
+		case 8:
+			var kv KeyValue
+			if err := kv.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.Tags = append(m.Tags, kv)
+		case 9:
+			var l Log
+			if err := l.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.Logs = append(m.Logs, l)
+		case 10:
+			m.Process = &Process{}
+			if err := m.Process.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		case 11:
+			m.ProcessID = string(f.bytes)
+		case 12:
+			m.Warnings = append(m.Warnings, string(f.bytes))
+		}
+	}
+	return nil
+}
+
+// Batch is a set of spans produced by a single Process, the unit submitted
+// to jaeger-collector in one PostSpans call.
+type Batch struct {
+	Spans   []*Span
+	Process *Process
+}
+
+func (m *Batch) Reset()         { *m = Batch{} }
+func (m *Batch) String() string { return fmt.Sprintf("%+v", *m) }
+func (*Batch) ProtoMessage()    {}
+
+func (m *Batch) Marshal() ([]byte, error) {
+	var dst []byte
+	for _, s := range m.Spans {
+		b, err := s.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 1, b)
+	}
+	if m.Process != nil {
+		b, err := m.Process.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		dst = appendMessage(dst, 2, b)
+	}
+	return dst, nil
+}
+
+func (m *Batch) Unmarshal(data []byte) error {
+	*m = Batch{}
+	for offset := 0; offset < len(data); {
+		f, next, err := nextField(data, offset)
+		if err != nil {
+			return err
+		}
+		offset = next
+		switch f.num {
+		case 1:
+			s := &Span{}
+			if err := s.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+			m.Spans = append(m.Spans, s)
+		case 2:
+			m.Process = &Process{}
+			if err := m.Process.Unmarshal(f.bytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
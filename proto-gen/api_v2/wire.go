@@ -0,0 +1,147 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_v2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+var errInvalidWireFormat = errors.New("api_v2: invalid wire format")
+
+func appendVarint(dst []byte, v uint64) []byte {
+	for v >= 0x80 {
+		dst = append(dst, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(dst, byte(v))
+}
+
+func appendTag(dst []byte, fieldNum, wireType int) []byte {
+	return appendVarint(dst, uint64(fieldNum)<<3|uint64(wireType))
+}
+
+func appendString(dst []byte, fieldNum int, s string) []byte {
+	if s == "" {
+		return dst
+	}
+	dst = appendTag(dst, fieldNum, wireBytes)
+	dst = appendVarint(dst, uint64(len(s)))
+	return append(dst, s...)
+}
+
+func appendBytes(dst []byte, fieldNum int, b []byte) []byte {
+	if len(b) == 0 {
+		return dst
+	}
+	dst = appendTag(dst, fieldNum, wireBytes)
+	dst = appendVarint(dst, uint64(len(b)))
+	return append(dst, b...)
+}
+
+func appendMessage(dst []byte, fieldNum int, msg []byte) []byte {
+	dst = appendTag(dst, fieldNum, wireBytes)
+	dst = appendVarint(dst, uint64(len(msg)))
+	return append(dst, msg...)
+}
+
+func appendVarintField(dst []byte, fieldNum int, v uint64) []byte {
+	if v == 0 {
+		return dst
+	}
+	dst = appendTag(dst, fieldNum, wireVarint)
+	return appendVarint(dst, v)
+}
+
+func appendFixed64(dst []byte, fieldNum int, v uint64) []byte {
+	if v == 0 {
+		return dst
+	}
+	dst = appendTag(dst, fieldNum, wireFixed64)
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(dst, b[:]...)
+}
+
+func consumeVarint(data []byte, offset int) (uint64, int, error) {
+	var v uint64
+	var shift uint
+	for {
+		if offset >= len(data) {
+			return 0, 0, errInvalidWireFormat
+		}
+		b := data[offset]
+		offset++
+		v |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return v, offset, nil
+		}
+		shift += 7
+		if shift >= 64 {
+			return 0, 0, errInvalidWireFormat
+		}
+	}
+}
+
+// wireField is one decoded (field number, value) pair read off the wire by
+// nextField; exactly one of varint/bytes is populated, per wire.
+type wireField struct {
+	num   int
+	wire  int
+	vint  uint64
+	bytes []byte
+}
+
+// nextField reads the next field's tag and payload starting at offset,
+// returning the field and the offset immediately following it.
+func nextField(data []byte, offset int) (wireField, int, error) {
+	tag, next, err := consumeVarint(data, offset)
+	if err != nil {
+		return wireField{}, offset, err
+	}
+	num, wire := int(tag>>3), int(tag&0x7)
+	switch wire {
+	case wireVarint:
+		v, next2, err := consumeVarint(data, next)
+		if err != nil {
+			return wireField{}, offset, err
+		}
+		return wireField{num: num, wire: wire, vint: v}, next2, nil
+	case wireBytes:
+		l, next2, err := consumeVarint(data, next)
+		if err != nil {
+			return wireField{}, offset, err
+		}
+		end := next2 + int(l)
+		if l > uint64(len(data)) || end < next2 || end > len(data) {
+			return wireField{}, offset, errInvalidWireFormat
+		}
+		return wireField{num: num, wire: wire, bytes: data[next2:end]}, end, nil
+	case wireFixed64:
+		if next+8 > len(data) {
+			return wireField{}, offset, errInvalidWireFormat
+		}
+		return wireField{num: num, wire: wire, vint: binary.LittleEndian.Uint64(data[next : next+8])}, next + 8, nil
+	default:
+		return wireField{}, offset, fmt.Errorf("api_v2: unsupported wire type %d", wire)
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Reporter is called by the tracer when a span is completed to report the
+// span to the tracing collector.
+type Reporter interface {
+	// Report submits a new span to collectors, possibly asynchronously and/or with buffering.
+	Report(span *Span)
+
+	// Close does a clean shutdown of the reporter, flushing any traces that may be buffered in memory.
+	Close()
+}
+
+// ReporterWithContext is a context-aware variant of Reporter. It lets a
+// caller bound how long Report/Close may block and cancel in-flight work,
+// e.g. to honor a Kubernetes SIGTERM grace period on shutdown.
+//
+// Built-in reporters implement this interface; their plain Report/Close
+// methods remain available and simply delegate to the *WithContext variants
+// using context.Background(), so existing callers of the Reporter interface
+// are unaffected.
+type ReporterWithContext interface {
+	Reporter
+
+	// ReportWithContext behaves like Report, but allows the caller to bound
+	// or cancel the call via ctx.
+	ReportWithContext(ctx context.Context, span *Span)
+
+	// CloseWithContext behaves like Close, but returns once ctx is done even
+	// if the flush has not finished, letting callers bound total shutdown
+	// time and cancel an in-flight collector RPC.
+	CloseWithContext(ctx context.Context) error
+}
+
+// ------------------------------
+
+type nullReporter struct{}
+
+// NewNullReporter creates a no-op reporter that ignores all reported spans.
+func NewNullReporter() ReporterWithContext {
+	return &nullReporter{}
+}
+
+func (r *nullReporter) Report(span *Span) {
+	// no-op
+}
+
+func (r *nullReporter) ReportWithContext(ctx context.Context, span *Span) {
+	// no-op
+}
+
+func (r *nullReporter) Close() {
+	// no-op
+}
+
+func (r *nullReporter) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// ------------------------------
+
+type loggingReporter struct {
+	logger Logger
+}
+
+// NewLoggingReporter creates a reporter that logs all reported spans to the provided logger.
+func NewLoggingReporter(logger Logger) ReporterWithContext {
+	return &loggingReporter{logger: logger}
+}
+
+func (r *loggingReporter) Report(span *Span) {
+	r.ReportWithContext(context.Background(), span)
+}
+
+func (r *loggingReporter) ReportWithContext(ctx context.Context, span *Span) {
+	r.logger.Infof("Reporting span %+v", span)
+}
+
+func (r *loggingReporter) Close() {
+	_ = r.CloseWithContext(context.Background())
+}
+
+func (r *loggingReporter) CloseWithContext(ctx context.Context) error {
+	return nil
+}
+
+// ------------- REMOTE REPORTER -----------------
+
+const (
+	defaultQueueSize           = 100
+	defaultBufferFlushInterval = time.Second
+)
+
+type reporterQueueItemType int
+
+const (
+	reporterQueueItemSpan reporterQueueItemType = iota
+	reporterQueueItemClose
+)
+
+type reporterQueueItem struct {
+	itemType reporterQueueItemType
+	span     *Span
+	done     chan struct{}
+	enqueued time.Time
+	// ctx is the caller's context for this item (the one passed to
+	// ReportWithContext/CloseWithContext), so processQueue can honor it when
+	// handing the item to the Transport instead of always falling back to
+	// context.Background().
+	ctx context.Context
+}
+
+type remoteReporter struct {
+	closed int64 // 0 - not closed, 1 - closed
+
+	reporterOptions
+
+	sender Transport
+	queue  chan reporterQueueItem
+}
+
+// NewRemoteReporter creates a new reporter that sends spans out of process by means of Transport.
+// Calls to Report(Span) return immediately (side effect: if internal buffer is full the span is dropped).
+// Periodically the transport buffer is flushed even if it hasn't reached max packet size.
+// Calls to Close() block until all spans reported prior to the call to Close are flushed.
+func NewRemoteReporter(sender Transport, opts ...ReporterOption) ReporterWithContext {
+	options := reporterOptions{}
+	for _, option := range opts {
+		option(&options)
+	}
+	if options.bufferFlushInterval <= 0 {
+		options.bufferFlushInterval = defaultBufferFlushInterval
+	}
+	if options.logger == nil {
+		options.logger = NullLogger
+	}
+	if options.queueSize <= 0 {
+		options.queueSize = defaultQueueSize
+	}
+	reporter := &remoteReporter{
+		reporterOptions: options,
+		sender:          sender,
+		queue:           make(chan reporterQueueItem, options.queueSize),
+	}
+	go reporter.processQueue()
+	return reporter
+}
+
+// Report implements Report() method of Reporter.
+func (r *remoteReporter) Report(span *Span) {
+	r.ReportWithContext(context.Background(), span)
+}
+
+// ReportWithContext implements ReportWithContext() of ReporterWithContext.
+// It passes the span to a background goroutine for submission to the Jaeger
+// backend. If the internal queue is full, the span is dropped. ctx is not
+// consulted here since the hand-off to the queue never blocks.
+func (r *remoteReporter) ReportWithContext(ctx context.Context, span *Span) {
+	select {
+	case r.queue <- reporterQueueItem{itemType: reporterQueueItemSpan, span: span, enqueued: time.Now(), ctx: ctx}:
+		if r.metrics != nil {
+			r.metrics.ReporterQueueLength.Update(int64(len(r.queue)))
+		}
+	default:
+		r.logger.Error("reporter queue full, dropping span")
+		if r.metrics != nil {
+			r.metrics.ReporterDropped.Inc(1)
+		}
+	}
+}
+
+// Close implements Close() method of Reporter by waiting for the queue to be drained.
+func (r *remoteReporter) Close() {
+	_ = r.CloseWithContext(context.Background())
+}
+
+// CloseWithContext implements CloseWithContext() of ReporterWithContext. It
+// waits for the queue to drain and the final flush to complete, but returns
+// early with ctx.Err() if ctx is done first, so callers can bound total
+// shutdown time.
+func (r *remoteReporter) CloseWithContext(ctx context.Context) error {
+	if swapped := atomic.CompareAndSwapInt64(&r.closed, 0, 1); !swapped {
+		r.logger.Error("repeated attempt to close the reporter is ignored")
+		return nil
+	}
+
+	done := make(chan struct{})
+	select {
+	case r.queue <- reporterQueueItem{itemType: reporterQueueItemClose, done: done, ctx: ctx}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if sender, ok := r.sender.(TransportWithContext); ok {
+		return sender.CloseWithContext(ctx)
+	}
+	return r.sender.Close()
+}
+
+// processQueue reads spans from the queue and hands them to the Transport.
+// The buffer is also flushed automatically every bufferFlushInterval, just
+// in case the tracer stopped reporting new spans.
+func (r *remoteReporter) processQueue() {
+	flush := func(ctx context.Context) {
+		start := time.Now()
+		var n int
+		var err error
+		if sender, ok := r.sender.(TransportWithContext); ok {
+			n, err = sender.FlushWithContext(ctx)
+		} else {
+			n, err = r.sender.Flush()
+		}
+		if r.metrics != nil {
+			r.metrics.TransportFlushLatency.recordValue(int64(time.Since(start)))
+			if n > 0 {
+				r.metrics.TransportBatchSize.recordValue(int64(n))
+			}
+		}
+		if err != nil {
+			r.logger.Error(fmt.Sprintf("failed to flush Jaeger spans to server: %s", err.Error()))
+		}
+	}
+
+	ticker := time.NewTicker(r.bufferFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			flush(context.Background())
+		case item := <-r.queue:
+			switch item.itemType {
+			case reporterQueueItemSpan:
+				if r.metrics != nil {
+					r.metrics.ReporterQueueLatency.recordValue(int64(time.Since(item.enqueued)))
+				}
+				start := time.Now()
+				var err error
+				if sender, ok := r.sender.(TransportWithContext); ok {
+					_, err = sender.AppendWithContext(item.ctx, item.span)
+				} else {
+					_, err = r.sender.Append(item.span)
+				}
+				if r.metrics != nil {
+					r.metrics.TransportAppendLatency.recordValue(int64(time.Since(start)))
+					if err != nil {
+						r.metrics.ReporterFailure.Inc(1)
+					} else {
+						r.metrics.ReporterSuccess.Inc(1)
+					}
+				}
+				if err != nil {
+					r.logger.Error(fmt.Sprintf("error reporting Jaeger span: %s", err.Error()))
+				}
+			case reporterQueueItemClose:
+				flush(item.ctx)
+				close(item.done)
+				return
+			}
+		}
+	}
+}
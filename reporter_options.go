@@ -0,0 +1,75 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import "time"
+
+// ReporterOption is a function that sets some option on the reporter.
+type ReporterOption func(c *reporterOptions)
+
+// ReporterOptions is a factory for all available ReporterOption's.
+var ReporterOptions ReporterOptionsFactory
+
+// ReporterOptionsFactory is a factory for all available ReporterOption's.
+// The type acts as a namespace for factory functions, made public so they
+// are discoverable via godoc; recommended to be used via the global
+// ReporterOptions variable.
+type ReporterOptionsFactory struct{}
+
+// reporterOptions control behavior of the reporter.
+type reporterOptions struct {
+	// queueSize is the size of internal queue where reported spans are stored before they are processed.
+	queueSize int
+	// bufferFlushInterval is how often the buffer is force-flushed, even if it's not full.
+	bufferFlushInterval time.Duration
+	// logger is used to log errors of span submissions.
+	logger Logger
+	// metrics, if set, records queue wait time, transport latency, and
+	// batch size histograms, plus the reporter_spans counters.
+	metrics *Metrics
+}
+
+// QueueSize creates a ReporterOption that sets the size of the internal
+// queue where spans are stored before they are processed.
+func (ReporterOptionsFactory) QueueSize(queueSize int) ReporterOption {
+	return func(r *reporterOptions) {
+		r.queueSize = queueSize
+	}
+}
+
+// BufferFlushInterval creates a ReporterOption that sets how often the
+// queue is force-flushed.
+func (ReporterOptionsFactory) BufferFlushInterval(bufferFlushInterval time.Duration) ReporterOption {
+	return func(r *reporterOptions) {
+		r.bufferFlushInterval = bufferFlushInterval
+	}
+}
+
+// Logger creates a ReporterOption that sets the logger used to log errors
+// of span submissions.
+func (ReporterOptionsFactory) Logger(logger Logger) ReporterOption {
+	return func(r *reporterOptions) {
+		r.logger = logger
+	}
+}
+
+// Metrics creates a ReporterOption that records the reporter's internal
+// instrumentation (queue wait time, transport latency, batch size, and the
+// reporter_spans counters) into the given Metrics.
+func (ReporterOptionsFactory) Metrics(m *Metrics) ReporterOption {
+	return func(r *reporterOptions) {
+		r.metrics = m
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withTestSpan(t *testing.T, f func(sp *Span)) {
+	tracer, closer := NewTracer("DOOP", NewConstSampler(true), NewNullReporter())
+	defer closer.Close()
+	sp := tracer.StartSpan("s1").(*Span)
+	defer sp.Finish()
+	f(sp)
+}
+
+// fakeTransport is a plain Transport; it does not implement
+// TransportWithContext, so RemoteReporter must fall back to it.
+type fakeTransport struct {
+	mux     sync.Mutex
+	spans   []*Span
+	flushes int
+	closed  bool
+}
+
+func (f *fakeTransport) Append(span *Span) (int, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.spans = append(f.spans, span)
+	return 0, nil
+}
+
+func (f *fakeTransport) Flush() (int, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.flushes++
+	n := len(f.spans)
+	f.spans = nil
+	return n, nil
+}
+
+func (f *fakeTransport) Close() error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.closed = true
+	return nil
+}
+
+func (f *fakeTransport) spanCount() int {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return len(f.spans)
+}
+
+// fakeTransportWithContext additionally implements TransportWithContext, so
+// RemoteReporter must prefer its ctx-aware methods over the plain ones.
+type fakeTransportWithContext struct {
+	fakeTransport
+	ctxCalls int
+}
+
+func (f *fakeTransportWithContext) AppendWithContext(ctx context.Context, span *Span) (int, error) {
+	f.ctxCalls++
+	return f.Append(span)
+}
+
+func (f *fakeTransportWithContext) FlushWithContext(ctx context.Context) (int, error) {
+	f.ctxCalls++
+	return f.Flush()
+}
+
+func (f *fakeTransportWithContext) CloseWithContext(ctx context.Context) error {
+	f.ctxCalls++
+	return f.Close()
+}
+
+func TestNullReporter(t *testing.T) {
+	reporter := NewNullReporter()
+	withTestSpan(t, func(sp *Span) {
+		reporter.Report(sp)
+		reporter.ReportWithContext(context.Background(), sp)
+	})
+	assert.NoError(t, reporter.CloseWithContext(context.Background()))
+	reporter.Close()
+}
+
+type inMemoryLogger struct {
+	mux   sync.Mutex
+	infos []string
+}
+
+func (l *inMemoryLogger) Error(msg string) {}
+
+func (l *inMemoryLogger) Infof(msg string, args ...interface{}) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	l.infos = append(l.infos, msg)
+}
+
+func TestLoggingReporter(t *testing.T) {
+	logger := new(inMemoryLogger)
+	reporter := NewLoggingReporter(logger)
+	withTestSpan(t, func(sp *Span) {
+		reporter.ReportWithContext(context.Background(), sp)
+	})
+	assert.Len(t, logger.infos, 1)
+	assert.NoError(t, reporter.CloseWithContext(context.Background()))
+}
+
+func TestRemoteReporterReportAndClose(t *testing.T) {
+	transport := new(fakeTransport)
+	reporter := NewRemoteReporter(transport, ReporterOptions.BufferFlushInterval(time.Hour))
+
+	withTestSpan(t, func(sp *Span) {
+		reporter.Report(sp)
+	})
+
+	require.NoError(t, reporter.CloseWithContext(context.Background()))
+	assert.Equal(t, 1, transport.flushes, "Close flushes any remaining spans")
+	assert.True(t, transport.closed)
+}
+
+func TestRemoteReporterPrefersTransportWithContext(t *testing.T) {
+	transport := new(fakeTransportWithContext)
+	reporter := NewRemoteReporter(transport, ReporterOptions.BufferFlushInterval(time.Hour))
+
+	withTestSpan(t, func(sp *Span) {
+		reporter.ReportWithContext(context.Background(), sp)
+	})
+
+	require.NoError(t, reporter.CloseWithContext(context.Background()))
+	assert.True(t, transport.ctxCalls > 0, "RemoteReporter should prefer the ctx-aware Transport methods")
+}
+
+func TestRemoteReporterCloseHonorsContextDeadline(t *testing.T) {
+	transport := new(fakeTransport)
+	reporter := NewRemoteReporter(transport, ReporterOptions.BufferFlushInterval(time.Hour))
+
+	// Close the reporter through a context that is already canceled, so
+	// CloseWithContext either completes or reports ctx.Err(); either way the
+	// call must not hang.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = reporter.CloseWithContext(ctx)
+}
+
+func TestRemoteReporterRepeatedCloseIsIgnored(t *testing.T) {
+	transport := new(fakeTransport)
+	reporter := NewRemoteReporter(transport, ReporterOptions.BufferFlushInterval(time.Hour))
+
+	require.NoError(t, reporter.CloseWithContext(context.Background()))
+	require.NoError(t, reporter.CloseWithContext(context.Background()))
+	assert.Equal(t, 1, transport.flushes, "second Close must not flush again")
+}
+
+func TestRemoteReporterRecordsTransportMetrics(t *testing.T) {
+	transport := new(fakeTransport)
+	m := NewNullMetrics()
+	reporter := NewRemoteReporter(transport,
+		ReporterOptions.BufferFlushInterval(time.Hour),
+		ReporterOptions.Metrics(m))
+
+	withTestSpan(t, func(sp *Span) {
+		reporter.Report(sp)
+	})
+	require.NoError(t, reporter.CloseWithContext(context.Background()))
+
+	snap := m.HistogramSnapshot()
+	assert.EqualValues(t, 1, snap.ReporterQueueLatency.Count, "span's queue wait time should be recorded")
+	assert.EqualValues(t, 1, snap.TransportAppendLatency.Count, "Append latency should be recorded")
+	assert.EqualValues(t, 1, snap.TransportFlushLatency.Count, "Flush latency should be recorded")
+	assert.EqualValues(t, 1, snap.TransportBatchSize.Count, "flushed batch size should be recorded")
+}
+
+// countingCounter is a jaeger-lib metrics.Counter that records its total so
+// tests can assert on it; jaeger-lib's own Counter interface has no getter.
+type countingCounter struct {
+	mux   sync.Mutex
+	total int64
+}
+
+func (c *countingCounter) Inc(delta int64) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.total += delta
+}
+
+func (c *countingCounter) value() int64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.total
+}
+
+func TestRemoteReporterIncrementsDroppedCounterOnQueueOverflow(t *testing.T) {
+	transport := &blockingTransport{unblock: make(chan struct{})}
+	dropped := new(countingCounter)
+	m := NewNullMetrics()
+	m.ReporterDropped = dropped
+	reporter := NewRemoteReporter(transport,
+		ReporterOptions.QueueSize(1),
+		ReporterOptions.BufferFlushInterval(time.Hour),
+		ReporterOptions.Metrics(m))
+	defer func() {
+		close(transport.unblock)
+		reporter.Close()
+	}()
+
+	// The background processing goroutine is blocked in Append, so every
+	// Report beyond the single queue slot it already took is guaranteed to
+	// find the queue full and be dropped.
+	withTestSpan(t, func(sp *Span) {
+		for i := 0; i < 10; i++ {
+			reporter.Report(sp)
+		}
+	})
+
+	assert.True(t, dropped.value() > 0, "expected at least one dropped span to be counted")
+}
+
+// blockingTransport blocks every Append until unblock is closed, so a
+// reporter with a small queue can be reliably driven to overflow.
+type blockingTransport struct {
+	fakeTransport
+	unblock chan struct{}
+}
+
+func (b *blockingTransport) Append(span *Span) (int, error) {
+	<-b.unblock
+	return b.fakeTransport.Append(span)
+}
+
+// ctxCapturingTransport records the context it receives in each
+// TransportWithContext method, so tests can assert a specific ctx (e.g. the
+// one passed to CloseWithContext) actually reached the Transport.
+type ctxCapturingTransport struct {
+	fakeTransport
+	mux        sync.Mutex
+	flushCtxs  []context.Context
+	appendCtxs []context.Context
+}
+
+func (c *ctxCapturingTransport) AppendWithContext(ctx context.Context, span *Span) (int, error) {
+	c.mux.Lock()
+	c.appendCtxs = append(c.appendCtxs, ctx)
+	c.mux.Unlock()
+	return c.fakeTransport.Append(span)
+}
+
+func (c *ctxCapturingTransport) FlushWithContext(ctx context.Context) (int, error) {
+	c.mux.Lock()
+	c.flushCtxs = append(c.flushCtxs, ctx)
+	c.mux.Unlock()
+	return c.fakeTransport.Flush()
+}
+
+func (c *ctxCapturingTransport) CloseWithContext(ctx context.Context) error {
+	return c.fakeTransport.Close()
+}
+
+func TestRemoteReporterCloseThreadsCallerContextToFlush(t *testing.T) {
+	transport := new(ctxCapturingTransport)
+	reporter := NewRemoteReporter(transport, ReporterOptions.BufferFlushInterval(time.Hour))
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "close-call")
+
+	require.NoError(t, reporter.CloseWithContext(ctx))
+
+	require.Len(t, transport.flushCtxs, 1)
+	assert.Equal(t, "close-call", transport.flushCtxs[0].Value(ctxKey{}), "the close-triggered flush should use the caller's context, not context.Background()")
+}
+
+func TestRemoteReporterReportThreadsCallerContextToAppend(t *testing.T) {
+	transport := new(ctxCapturingTransport)
+	reporter := NewRemoteReporter(transport, ReporterOptions.BufferFlushInterval(time.Hour))
+	defer reporter.Close()
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "report-call")
+
+	withTestSpan(t, func(sp *Span) {
+		reporter.ReportWithContext(ctx, sp)
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		transport.mux.Lock()
+		n := len(transport.appendCtxs)
+		transport.mux.Unlock()
+		if n == 1 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	transport.mux.Lock()
+	defer transport.mux.Unlock()
+	require.Len(t, transport.appendCtxs, 1)
+	assert.Equal(t, "report-call", transport.appendCtxs[0].Value(ctxKey{}))
+}
@@ -0,0 +1,421 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Sampler decides whether a new trace should be sampled or not.
+type Sampler interface {
+	// IsSampled returns whether a trace with the given ID should be sampled.
+	//
+	// Deprecated: use IsSampledWithOperation, which lets samplers that care
+	// about the root operation name (e.g. PerOperationSampler) make a more
+	// informed decision. Kept for callers that only have a trace ID.
+	IsSampled(id TraceID) (sampled bool, tags []Tag)
+
+	// IsSampledWithOperation returns whether a trace with the given ID and
+	// root operation name should be sampled. Samplers that do not
+	// distinguish by operation are free to ignore the operation argument.
+	IsSampledWithOperation(id TraceID, operation string) (sampled bool, tags []Tag)
+
+	// Close does a clean shutdown of the sampler, stopping any background
+	// goroutines it may have started.
+	Close()
+}
+
+// Tag and NewTag are defined elsewhere in the package (alongside Span) and
+// reused here to annotate the root span with the sampling decision that
+// was made.
+
+// -----------------------
+
+// ConstSampler is a sampler that always makes the same decision.
+type ConstSampler struct {
+	Decision bool
+	tags     []Tag
+}
+
+// NewConstSampler creates a ConstSampler.
+func NewConstSampler(sample bool) Sampler {
+	return &ConstSampler{
+		Decision: sample,
+		tags: []Tag{
+			NewTag(SamplerTypeTagKey, SamplerTypeConst),
+			NewTag(SamplerParamTagKey, sample),
+		},
+	}
+}
+
+// IsSampled implements IsSampled() of Sampler.
+func (s *ConstSampler) IsSampled(id TraceID) (bool, []Tag) {
+	return s.Decision, s.tags
+}
+
+// IsSampledWithOperation implements IsSampledWithOperation() of Sampler.
+func (s *ConstSampler) IsSampledWithOperation(id TraceID, operation string) (bool, []Tag) {
+	return s.Decision, s.tags
+}
+
+// Close implements Close() of Sampler.
+func (s *ConstSampler) Close() {
+	// nothing to do
+}
+
+// -----------------------
+
+// ProbabilisticSampler is a sampler that randomly samples a certain
+// percentage of traces.
+type ProbabilisticSampler struct {
+	samplingRate     float64
+	samplingBoundary uint64
+	tags             []Tag
+}
+
+// maxRandomNumber is the largest 63bit random number, used to turn a trace
+// ID into a sampling decision without generating a fresh random number.
+const maxRandomNumber = ^(uint64(1) << 63)
+
+// NewProbabilisticSampler creates a sampler that randomly samples a certain
+// percentage of traces specified by samplingRate, in the range [0.0, 1.0].
+func NewProbabilisticSampler(samplingRate float64) (*ProbabilisticSampler, error) {
+	if samplingRate < 0.0 || samplingRate > 1.0 {
+		return nil, fmt.Errorf("sampling rate must be between 0.0 and 1.0, received %f", samplingRate)
+	}
+	return newProbabilisticSampler(samplingRate), nil
+}
+
+func newProbabilisticSampler(samplingRate float64) *ProbabilisticSampler {
+	samplingRate = math.Max(0.0, math.Min(samplingRate, 1.0))
+	return &ProbabilisticSampler{
+		samplingRate:     samplingRate,
+		samplingBoundary: uint64(float64(maxRandomNumber) * samplingRate),
+		tags: []Tag{
+			NewTag(SamplerTypeTagKey, SamplerTypeProbabilistic),
+			NewTag(SamplerParamTagKey, samplingRate),
+		},
+	}
+}
+
+// SamplingRate returns the sampling probability this sampler was constructed with.
+func (s *ProbabilisticSampler) SamplingRate() float64 {
+	return s.samplingRate
+}
+
+// IsSampled implements IsSampled() of Sampler.
+func (s *ProbabilisticSampler) IsSampled(id TraceID) (bool, []Tag) {
+	return s.samplingBoundary >= id.Low&maxRandomNumber, s.tags
+}
+
+// IsSampledWithOperation implements IsSampledWithOperation() of Sampler.
+func (s *ProbabilisticSampler) IsSampledWithOperation(id TraceID, operation string) (bool, []Tag) {
+	return s.IsSampled(id)
+}
+
+// Close implements Close() of Sampler.
+func (s *ProbabilisticSampler) Close() {
+	// nothing to do
+}
+
+// -----------------------
+
+// leakyBucket is a minimal leaky-bucket rate limiter: its balance is
+// replenished over time up to maxBalance, and CheckCredit spends from that
+// balance. It backs RateLimitingSampler and the per-operation lower bound
+// in PerOperationSampler.
+type leakyBucket struct {
+	mux sync.Mutex
+
+	creditsPerSecond float64
+	balance          float64
+	maxBalance       float64
+	lastTick         time.Time
+
+	timeNow func() time.Time
+}
+
+func newLeakyBucket(creditsPerSecond, maxBalance float64) *leakyBucket {
+	return &leakyBucket{
+		creditsPerSecond: creditsPerSecond,
+		balance:          maxBalance,
+		maxBalance:       maxBalance,
+		lastTick:         time.Now(),
+		timeNow:          time.Now,
+	}
+}
+
+// Update adjusts the bucket's fill rate and capacity to
+// newCreditsPerSecond, rescaling the current balance proportionally
+// instead of resetting it to the new capacity. Rebuilding the bucket from
+// scratch on every rate change would refill it to full balance, letting a
+// routine strategy poll grant a burst of "free" credit; rescaling keeps
+// the bucket's fullness (e.g. half-full stays half-full) across the
+// change.
+func (b *leakyBucket) Update(newCreditsPerSecond float64) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	newMaxBalance := math.Max(newCreditsPerSecond, 1.0)
+	if b.maxBalance > 0 {
+		b.balance = b.balance * newMaxBalance / b.maxBalance
+	} else {
+		b.balance = newMaxBalance
+	}
+	b.creditsPerSecond = newCreditsPerSecond
+	b.maxBalance = newMaxBalance
+}
+
+func (b *leakyBucket) CheckCredit(itemCost float64) bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	now := b.timeNow()
+	elapsed := now.Sub(b.lastTick)
+	b.lastTick = now
+	b.balance += elapsed.Seconds() * b.creditsPerSecond
+	if b.balance > b.maxBalance {
+		b.balance = b.maxBalance
+	}
+	if b.balance < itemCost {
+		return false
+	}
+	b.balance -= itemCost
+	return true
+}
+
+// -----------------------
+
+// RateLimitingSampler samples at most maxTracesPerSecond. The distribution
+// of sampled traces follows the burstiness of the service: requests spread
+// uniformly over time are sampled uniformly, but a burst of requests can be
+// sampled in full up to the bucket's balance.
+type RateLimitingSampler struct {
+	maxTracesPerSecond float64
+	bucket             *leakyBucket
+	tags               []Tag
+}
+
+// NewRateLimitingSampler creates a sampler that samples at most
+// maxTracesPerSecond.
+func NewRateLimitingSampler(maxTracesPerSecond float64) *RateLimitingSampler {
+	return &RateLimitingSampler{
+		maxTracesPerSecond: maxTracesPerSecond,
+		bucket:             newLeakyBucket(maxTracesPerSecond, math.Max(maxTracesPerSecond, 1.0)),
+		tags: []Tag{
+			NewTag(SamplerTypeTagKey, SamplerTypeRateLimiting),
+			NewTag(SamplerParamTagKey, maxTracesPerSecond),
+		},
+	}
+}
+
+// IsSampled implements IsSampled() of Sampler.
+func (s *RateLimitingSampler) IsSampled(id TraceID) (bool, []Tag) {
+	return s.bucket.CheckCredit(1.0), s.tags
+}
+
+// IsSampledWithOperation implements IsSampledWithOperation() of Sampler.
+func (s *RateLimitingSampler) IsSampledWithOperation(id TraceID, operation string) (bool, []Tag) {
+	return s.IsSampled(id)
+}
+
+// Close implements Close() of Sampler.
+func (s *RateLimitingSampler) Close() {
+	// nothing to do
+}
+
+// Update adjusts maxTracesPerSecond in place, rescaling the underlying
+// leaky bucket's balance rather than resetting it, so a rate change alone
+// never grants a fresh burst of credit.
+func (s *RateLimitingSampler) Update(maxTracesPerSecond float64) {
+	s.maxTracesPerSecond = maxTracesPerSecond
+	s.bucket.Update(maxTracesPerSecond)
+	s.tags = []Tag{
+		NewTag(SamplerTypeTagKey, SamplerTypeRateLimiting),
+		NewTag(SamplerParamTagKey, maxTracesPerSecond),
+	}
+}
+
+// -----------------------
+
+// GuaranteedThroughputProbabilisticSampler pairs a ProbabilisticSampler with
+// a RateLimitingSampler used as a guaranteed lower bound, so that an
+// operation is sampled at least once per interval defined by lowerBound
+// (e.g. a lowerBound of 1.0/60 samples an operation at least once a
+// minute), even if the probabilistic sampler never fires.
+//
+// The probabilistic sampler's tags take priority when both samplers agree
+// to sample.
+type GuaranteedThroughputProbabilisticSampler struct {
+	probabilisticSampler *ProbabilisticSampler
+	lowerBoundSampler    *RateLimitingSampler
+	tags                 []Tag
+	samplingRate         float64
+	lowerBound           float64
+}
+
+func newGuaranteedThroughputProbabilisticSampler(lowerBound, samplingRate float64) *GuaranteedThroughputProbabilisticSampler {
+	s := &GuaranteedThroughputProbabilisticSampler{
+		lowerBoundSampler: NewRateLimitingSampler(lowerBound),
+		lowerBound:        lowerBound,
+	}
+	s.setProbabilisticSampler(samplingRate)
+	return s
+}
+
+func (s *GuaranteedThroughputProbabilisticSampler) setProbabilisticSampler(samplingRate float64) {
+	if s.probabilisticSampler == nil || s.samplingRate != samplingRate {
+		s.probabilisticSampler = newProbabilisticSampler(samplingRate)
+		s.samplingRate = s.probabilisticSampler.SamplingRate()
+		s.tags = []Tag{
+			NewTag(SamplerTypeTagKey, SamplerTypeLowerBound),
+			NewTag(SamplerParamTagKey, s.samplingRate),
+		}
+	}
+}
+
+// IsSampled implements IsSampled() of Sampler.
+func (s *GuaranteedThroughputProbabilisticSampler) IsSampled(id TraceID) (bool, []Tag) {
+	return s.IsSampledWithOperation(id, "")
+}
+
+// IsSampledWithOperation implements IsSampledWithOperation() of Sampler.
+func (s *GuaranteedThroughputProbabilisticSampler) IsSampledWithOperation(id TraceID, operation string) (bool, []Tag) {
+	if sampled, tags := s.probabilisticSampler.IsSampled(id); sampled {
+		// still spend the lower bound's credit so a probabilistic hit
+		// counts toward the guaranteed throughput interval.
+		s.lowerBoundSampler.IsSampled(id)
+		return true, tags
+	}
+	sampled, _ := s.lowerBoundSampler.IsSampled(id)
+	return sampled, s.tags
+}
+
+// Close implements Close() of Sampler.
+func (s *GuaranteedThroughputProbabilisticSampler) Close() {
+	s.probabilisticSampler.Close()
+	s.lowerBoundSampler.Close()
+}
+
+// update applies newly polled lowerBound/samplingRate in place, while
+// caller holds whatever lock guards this sampler.
+func (s *GuaranteedThroughputProbabilisticSampler) update(lowerBound, samplingRate float64) {
+	s.setProbabilisticSampler(samplingRate)
+	if s.lowerBound != lowerBound {
+		s.lowerBoundSampler.Update(lowerBound)
+		s.lowerBound = lowerBound
+	}
+}
+
+// -----------------------
+
+// PerOperationSampler keeps a GuaranteedThroughputProbabilisticSampler per
+// operation name, up to maxOperations distinct operations, so that each
+// operation is sampled at its own probability while still guaranteeing a
+// minimum throughput. Operations beyond maxOperations, and any not yet
+// seen once that limit is hit, fall back to a default probabilistic
+// sampler.
+type PerOperationSampler struct {
+	sync.RWMutex
+
+	samplers       map[string]*GuaranteedThroughputProbabilisticSampler
+	defaultSampler *ProbabilisticSampler
+	lowerBound     float64
+	maxOperations  int
+}
+
+// NewPerOperationSampler returns a sampler that delegates sampling
+// decisions to a per-operation GuaranteedThroughputProbabilisticSampler,
+// seeded from strategies.
+func NewPerOperationSampler(strategies *PerOperationSamplingStrategies, maxOperations int) *PerOperationSampler {
+	samplers := make(map[string]*GuaranteedThroughputProbabilisticSampler)
+	for _, strategy := range strategies.PerOperationStrategies {
+		samplers[strategy.Operation] = newGuaranteedThroughputProbabilisticSampler(
+			strategies.DefaultLowerBoundTracesPerSecond,
+			strategy.ProbabilisticSampling.SamplingRate,
+		)
+	}
+	return &PerOperationSampler{
+		samplers:       samplers,
+		defaultSampler: newProbabilisticSampler(strategies.DefaultSamplingProbability),
+		lowerBound:     strategies.DefaultLowerBoundTracesPerSecond,
+		maxOperations:  maxOperations,
+	}
+}
+
+// IsSampled implements IsSampled() of Sampler.
+func (s *PerOperationSampler) IsSampled(id TraceID) (bool, []Tag) {
+	return s.IsSampledWithOperation(id, "")
+}
+
+// IsSampledWithOperation implements IsSampledWithOperation() of Sampler.
+func (s *PerOperationSampler) IsSampledWithOperation(id TraceID, operation string) (bool, []Tag) {
+	s.RLock()
+	sampler, ok := s.samplers[operation]
+	if ok {
+		defer s.RUnlock()
+		return sampler.IsSampledWithOperation(id, operation)
+	}
+	s.RUnlock()
+
+	s.Lock()
+	defer s.Unlock()
+	// re-check under write lock in case another goroutine created it first.
+	if sampler, ok = s.samplers[operation]; ok {
+		return sampler.IsSampledWithOperation(id, operation)
+	}
+	if len(s.samplers) >= s.maxOperations {
+		return s.defaultSampler.IsSampled(id)
+	}
+	newSampler := newGuaranteedThroughputProbabilisticSampler(s.lowerBound, s.defaultSampler.SamplingRate())
+	s.samplers[operation] = newSampler
+	return newSampler.IsSampledWithOperation(id, operation)
+}
+
+// Close implements Close() of Sampler.
+func (s *PerOperationSampler) Close() {
+	s.Lock()
+	defer s.Unlock()
+	for _, sampler := range s.samplers {
+		sampler.Close()
+	}
+	s.defaultSampler.Close()
+}
+
+// update applies a freshly polled set of strategies in place, reusing
+// existing per-operation samplers where possible instead of discarding
+// their lower-bound state.
+func (s *PerOperationSampler) update(strategies *PerOperationSamplingStrategies) {
+	s.Lock()
+	defer s.Unlock()
+
+	lowerBound := strategies.DefaultLowerBoundTracesPerSecond
+	for _, strategy := range strategies.PerOperationStrategies {
+		if sampler, ok := s.samplers[strategy.Operation]; ok {
+			sampler.update(lowerBound, strategy.ProbabilisticSampling.SamplingRate)
+		} else if len(s.samplers) < s.maxOperations {
+			s.samplers[strategy.Operation] = newGuaranteedThroughputProbabilisticSampler(
+				lowerBound, strategy.ProbabilisticSampling.SamplingRate,
+			)
+		}
+	}
+	s.lowerBound = lowerBound
+	if s.defaultSampler.SamplingRate() != strategies.DefaultSamplingProbability {
+		s.defaultSampler = newProbabilisticSampler(strategies.DefaultSamplingProbability)
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSamplingRefreshInterval = time.Minute
+	defaultMaxOperations           = 2000
+)
+
+// ProbabilisticSamplingStrategy is the "probabilistic" field of a
+// SamplingStrategyResponse.
+type ProbabilisticSamplingStrategy struct {
+	SamplingRate float64 `json:"samplingRate"`
+}
+
+// RateLimitingSamplingStrategy is the "rateLimiting" field of a
+// SamplingStrategyResponse.
+type RateLimitingSamplingStrategy struct {
+	MaxTracesPerSecond float64 `json:"maxTracesPerSecond"`
+}
+
+// OperationSamplingStrategy is a single entry of
+// PerOperationSamplingStrategies.PerOperationStrategies.
+type OperationSamplingStrategy struct {
+	Operation             string                         `json:"operation"`
+	ProbabilisticSampling *ProbabilisticSamplingStrategy `json:"probabilisticSampling"`
+}
+
+// PerOperationSamplingStrategies is the "perOperationStrategies" field of a
+// SamplingStrategyResponse.
+type PerOperationSamplingStrategies struct {
+	DefaultSamplingProbability       float64                      `json:"defaultSamplingProbability"`
+	DefaultLowerBoundTracesPerSecond float64                      `json:"defaultLowerBoundTracesPerSecond"`
+	PerOperationStrategies           []*OperationSamplingStrategy `json:"perOperationStrategies"`
+}
+
+// SamplingStrategyResponse is the JSON schema returned by a sampling
+// strategy endpoint (e.g. jaeger-agent's /sampling). Exactly one of its
+// fields is expected to be populated.
+type SamplingStrategyResponse struct {
+	ProbabilisticSampling *ProbabilisticSamplingStrategy  `json:"probabilistic,omitempty"`
+	RateLimitingSampling  *RateLimitingSamplingStrategy   `json:"rateLimiting,omitempty"`
+	OperationSampling     *PerOperationSamplingStrategies `json:"perOperationStrategies,omitempty"`
+}
+
+// SamplingStrategyFetcher retrieves the sampling strategy for serviceName
+// from wherever it is configured. The default implementation polls an HTTP
+// endpoint; tests substitute their own fetcher.
+type SamplingStrategyFetcher interface {
+	Fetch(serviceName string) (*SamplingStrategyResponse, error)
+}
+
+type httpSamplingStrategyFetcher struct {
+	serverURL string
+}
+
+func (f *httpSamplingStrategyFetcher) Fetch(serviceName string) (*SamplingStrategyResponse, error) {
+	v := url.Values{}
+	v.Set("service", serviceName)
+	resp, err := http.Get(f.serverURL + "?" + v.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("sampling strategy endpoint returned status %d", resp.StatusCode)
+	}
+	var out SamplingStrategyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SamplerOption is a function that sets some option on the sampler.
+type SamplerOption func(options *samplerOptions)
+
+// SamplerOptions is a factory for all available SamplerOption's.
+var SamplerOptions SamplerOptionsFactory
+
+// SamplerOptionsFactory is a factory for all available SamplerOption's. The
+// type acts as a namespace for factory functions, made public so they are
+// discoverable via godoc; recommended to be used via the global
+// SamplerOptions variable.
+type SamplerOptionsFactory struct{}
+
+type samplerOptions struct {
+	initialSampler  Sampler
+	fetcher         SamplingStrategyFetcher
+	maxOperations   int
+	refreshInterval time.Duration
+}
+
+// InitialSampler creates a SamplerOption that sets the sampler used before
+// the first successful poll of the sampling strategy endpoint.
+func (SamplerOptionsFactory) InitialSampler(sampler Sampler) SamplerOption {
+	return func(o *samplerOptions) { o.initialSampler = sampler }
+}
+
+// Fetcher creates a SamplerOption that overrides how sampling strategies
+// are retrieved, e.g. to stub the endpoint in tests.
+func (SamplerOptionsFactory) Fetcher(fetcher SamplingStrategyFetcher) SamplerOption {
+	return func(o *samplerOptions) { o.fetcher = fetcher }
+}
+
+// MaxOperations creates a SamplerOption that sets the maximum number of
+// distinct operation names a PerOperationSampler will track.
+func (SamplerOptionsFactory) MaxOperations(maxOperations int) SamplerOption {
+	return func(o *samplerOptions) { o.maxOperations = maxOperations }
+}
+
+// RefreshInterval creates a SamplerOption that sets how often the sampler
+// polls the sampling strategy endpoint.
+func (SamplerOptionsFactory) RefreshInterval(refreshInterval time.Duration) SamplerOption {
+	return func(o *samplerOptions) { o.refreshInterval = refreshInterval }
+}
+
+func applySamplerOptions(serverURL string, opts ...SamplerOption) samplerOptions {
+	options := samplerOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.initialSampler == nil {
+		options.initialSampler = newProbabilisticSampler(0.001)
+	}
+	if options.fetcher == nil {
+		options.fetcher = &httpSamplingStrategyFetcher{serverURL: serverURL}
+	}
+	if options.maxOperations <= 0 {
+		options.maxOperations = defaultMaxOperations
+	}
+	if options.refreshInterval <= 0 {
+		options.refreshInterval = defaultSamplingRefreshInterval
+	}
+	return options
+}
+
+// RemotelyControlledSampler is a delegating sampler that periodically polls
+// a sampling strategy endpoint and hot-swaps its delegate sampler to match
+// the strategy it receives, without the caller ever observing a gap in
+// sampling decisions.
+type RemotelyControlledSampler struct {
+	sync.RWMutex
+	samplerOptions
+
+	serviceName string
+	sampler     Sampler
+
+	closeOnce sync.Once
+	doneChan  chan struct{}
+}
+
+// NewRemotelyControlledSampler creates a sampler that periodically polls
+// serverURL for the sampling strategy to use for serviceName.
+func NewRemotelyControlledSampler(serviceName, serverURL string, opts ...SamplerOption) *RemotelyControlledSampler {
+	options := applySamplerOptions(serverURL, opts...)
+	s := &RemotelyControlledSampler{
+		samplerOptions: options,
+		serviceName:    serviceName,
+		sampler:        options.initialSampler,
+		doneChan:       make(chan struct{}),
+	}
+	go s.pollController()
+	return s
+}
+
+// IsSampled implements IsSampled() of Sampler.
+func (s *RemotelyControlledSampler) IsSampled(id TraceID) (bool, []Tag) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sampler.IsSampled(id)
+}
+
+// IsSampledWithOperation implements IsSampledWithOperation() of Sampler.
+func (s *RemotelyControlledSampler) IsSampledWithOperation(id TraceID, operation string) (bool, []Tag) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.sampler.IsSampledWithOperation(id, operation)
+}
+
+// Close implements Close() of Sampler, stopping the polling goroutine.
+func (s *RemotelyControlledSampler) Close() {
+	s.closeOnce.Do(func() {
+		close(s.doneChan)
+	})
+}
+
+func (s *RemotelyControlledSampler) pollController() {
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.updateSampler()
+		case <-s.doneChan:
+			s.Lock()
+			s.sampler.Close()
+			s.Unlock()
+			return
+		}
+	}
+}
+
+// updateSampler polls the fetcher once and, on success, swaps in a new
+// delegate sampler reflecting the response. It is also exercised directly
+// by tests, which don't want to wait out refreshInterval.
+func (s *RemotelyControlledSampler) updateSampler() error {
+	res, err := s.fetcher.Fetch(s.serviceName)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if strategies := res.OperationSampling; strategies != nil {
+		if perOp, ok := s.sampler.(*PerOperationSampler); ok {
+			perOp.update(strategies)
+		} else {
+			s.sampler = NewPerOperationSampler(strategies, s.maxOperations)
+		}
+		return nil
+	}
+	if probabilistic := res.ProbabilisticSampling; probabilistic != nil {
+		s.sampler = newProbabilisticSampler(probabilistic.SamplingRate)
+		return nil
+	}
+	if rateLimiting := res.RateLimitingSampling; rateLimiting != nil {
+		s.sampler = NewRateLimitingSampler(rateLimiting.MaxTracesPerSecond)
+		return nil
+	}
+	return fmt.Errorf("sampling strategy response contained no recognized strategy")
+}
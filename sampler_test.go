@@ -0,0 +1,233 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstSamplerForceSampled(t *testing.T) {
+	sampler := NewConstSampler(true)
+	sampled, tags := sampler.IsSampledWithOperation(TraceID{Low: 1}, "some-op")
+	assert.True(t, sampled)
+	assert.Equal(t, []Tag{
+		NewTag(SamplerTypeTagKey, SamplerTypeConst),
+		NewTag(SamplerParamTagKey, true),
+	}, tags)
+	sampler.Close()
+}
+
+func TestProbabilisticSamplerErrors(t *testing.T) {
+	_, err := NewProbabilisticSampler(-0.1)
+	assert.Error(t, err)
+	_, err = NewProbabilisticSampler(1.1)
+	assert.Error(t, err)
+}
+
+func TestProbabilisticSamplerBoundary(t *testing.T) {
+	sampler, err := NewProbabilisticSampler(0.5)
+	require.NoError(t, err)
+	sampled, _ := sampler.IsSampled(TraceID{Low: 0})
+	assert.True(t, sampled, "trace ID 0 is always sampled")
+	sampled, _ = sampler.IsSampled(TraceID{Low: maxRandomNumber})
+	assert.False(t, sampled, "trace ID at the boundary is never sampled at rate 0.5")
+}
+
+// TestProbabilisticSamplerHighBit verifies that a trace ID with the high bit
+// set (roughly half of all randomly generated trace IDs) is still sampled at
+// rate 1.0. id.Low must be masked with maxRandomNumber before comparing
+// against samplingBoundary, since samplingBoundary is always a 63bit value
+// and can never compare >= an unmasked 64bit id.Low with the high bit set.
+func TestProbabilisticSamplerHighBit(t *testing.T) {
+	sampler, err := NewProbabilisticSampler(1.0)
+	require.NoError(t, err)
+	sampled, _ := sampler.IsSampled(TraceID{Low: uint64(1)<<63 | 12345})
+	assert.True(t, sampled, "trace ID with the high bit set is always sampled at rate 1.0")
+}
+
+func TestRateLimitingSamplerBurst(t *testing.T) {
+	sampler := NewRateLimitingSampler(2)
+	sampled, _ := sampler.IsSampled(TraceID{Low: 1})
+	assert.True(t, sampled)
+	sampled, _ = sampler.IsSampled(TraceID{Low: 2})
+	assert.True(t, sampled)
+	sampled, _ = sampler.IsSampled(TraceID{Low: 3})
+	assert.False(t, sampled, "burst beyond the configured rate is not sampled")
+}
+
+// TestPerOperationSamplerLowerBound verifies that an operation whose
+// probabilistic sampler never fires still samples at least once per burst,
+// guaranteed by the per-operation lower bound, analogous to
+// TestThriftForceSampled's check that a forced decision survives building
+// the span.
+func TestPerOperationSamplerLowerBound(t *testing.T) {
+	strategies := &PerOperationSamplingStrategies{
+		DefaultSamplingProbability:       0,
+		DefaultLowerBoundTracesPerSecond: 2,
+		PerOperationStrategies: []*OperationSamplingStrategy{
+			{
+				Operation:             "noisy-op",
+				ProbabilisticSampling: &ProbabilisticSamplingStrategy{SamplingRate: 0},
+			},
+		},
+	}
+	sampler := NewPerOperationSampler(strategies, 100)
+	defer sampler.Close()
+
+	sampled, _ := sampler.IsSampledWithOperation(TraceID{Low: 1}, "noisy-op")
+	assert.True(t, sampled, "lower bound guarantees the first trace of a burst is sampled")
+	sampled, _ = sampler.IsSampledWithOperation(TraceID{Low: 2}, "noisy-op")
+	assert.True(t, sampled, "lower bound guarantees the second trace of a burst is sampled")
+	sampled, _ = sampler.IsSampledWithOperation(TraceID{Low: 3}, "noisy-op")
+	assert.False(t, sampled, "lower bound is exhausted for the rest of the burst")
+}
+
+func TestPerOperationSamplerFallsBackToDefault(t *testing.T) {
+	strategies := &PerOperationSamplingStrategies{
+		DefaultSamplingProbability:       1,
+		DefaultLowerBoundTracesPerSecond: 0,
+	}
+	sampler := NewPerOperationSampler(strategies, 1)
+	defer sampler.Close()
+
+	sampled, tags := sampler.IsSampledWithOperation(TraceID{Low: 1}, "unseen-op")
+	assert.True(t, sampled)
+	assert.Equal(t, SamplerTypeProbabilistic, tags[0].value)
+}
+
+func TestPerOperationSamplerMaxOperations(t *testing.T) {
+	strategies := &PerOperationSamplingStrategies{DefaultSamplingProbability: 0}
+	sampler := NewPerOperationSampler(strategies, 1)
+	defer sampler.Close()
+
+	sampler.IsSampledWithOperation(TraceID{Low: 1}, "op-a")
+	sampler.IsSampledWithOperation(TraceID{Low: 1}, "op-b")
+
+	sampler.RLock()
+	numSamplers := len(sampler.samplers)
+	sampler.RUnlock()
+	assert.Equal(t, 1, numSamplers, "second operation exceeds maxOperations and is not tracked")
+}
+
+type fakeSamplingStrategyFetcher struct {
+	mux       sync.Mutex
+	responses []*SamplingStrategyResponse
+	calls     int
+}
+
+func (f *fakeSamplingStrategyFetcher) Fetch(serviceName string) (*SamplingStrategyResponse, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if f.calls >= len(f.responses) {
+		return f.responses[len(f.responses)-1], nil
+	}
+	res := f.responses[f.calls]
+	f.calls++
+	return res, nil
+}
+
+func TestRemotelyControlledSamplerHotSwapsDelegate(t *testing.T) {
+	fetcher := &fakeSamplingStrategyFetcher{
+		responses: []*SamplingStrategyResponse{
+			{ProbabilisticSampling: &ProbabilisticSamplingStrategy{SamplingRate: 1}},
+		},
+	}
+	sampler := NewRemotelyControlledSampler(
+		"some-service",
+		DefaultSamplingServerURL,
+		SamplerOptions.InitialSampler(NewConstSampler(false)),
+		SamplerOptions.Fetcher(fetcher),
+		SamplerOptions.RefreshInterval(time.Hour),
+	)
+	defer sampler.Close()
+
+	sampled, _ := sampler.IsSampled(TraceID{Low: 1})
+	assert.False(t, sampled, "initial sampler is used until the first poll succeeds")
+
+	require.NoError(t, sampler.updateSampler())
+
+	sampled, _ = sampler.IsSampled(TraceID{Low: 1})
+	assert.True(t, sampled, "sampler is hot-swapped to the polled probabilistic strategy")
+}
+
+func TestRemotelyControlledSamplerPerOperation(t *testing.T) {
+	fetcher := &fakeSamplingStrategyFetcher{
+		responses: []*SamplingStrategyResponse{
+			{
+				OperationSampling: &PerOperationSamplingStrategies{
+					DefaultSamplingProbability: 0,
+					PerOperationStrategies: []*OperationSamplingStrategy{
+						{
+							Operation:             "important-op",
+							ProbabilisticSampling: &ProbabilisticSamplingStrategy{SamplingRate: 1},
+						},
+					},
+				},
+			},
+		},
+	}
+	sampler := NewRemotelyControlledSampler(
+		"some-service",
+		DefaultSamplingServerURL,
+		SamplerOptions.Fetcher(fetcher),
+		SamplerOptions.RefreshInterval(time.Hour),
+	)
+	defer sampler.Close()
+
+	require.NoError(t, sampler.updateSampler())
+
+	sampled, _ := sampler.IsSampledWithOperation(TraceID{Low: 1}, "important-op")
+	assert.True(t, sampled)
+
+	// "other-op" falls back to the default probabilistic sampler (rate 0),
+	// but the first decision for a never-seen operation still rides the
+	// initial lower-bound burst; the second must not.
+	sampler.IsSampledWithOperation(TraceID{Low: 1}, "other-op")
+	sampled, _ = sampler.IsSampledWithOperation(TraceID{Low: 1}, "other-op")
+	assert.False(t, sampled)
+}
+
+func TestLeakyBucketUpdateRescalesBalanceInsteadOfResetting(t *testing.T) {
+	b := newLeakyBucket(10, 10)
+	// Exhaust the balance entirely.
+	for i := 0; i < 10; i++ {
+		require.True(t, b.CheckCredit(1))
+	}
+	require.False(t, b.CheckCredit(1), "bucket should be out of balance")
+
+	// Doubling the rate should scale the remaining balance proportionally,
+	// not refill it to the new max.
+	b.Update(20)
+	require.False(t, b.CheckCredit(5), "rescaled balance should still be small, not reset to the new max of 20")
+}
+
+func TestGuaranteedThroughputSamplerUpdateRescalesLowerBound(t *testing.T) {
+	s := newGuaranteedThroughputProbabilisticSampler(10, 0)
+	// Exhaust the lower-bound sampler's balance.
+	for i := 0; i < 10; i++ {
+		s.lowerBoundSampler.IsSampled(TraceID{})
+	}
+	sampled, _ := s.lowerBoundSampler.IsSampled(TraceID{})
+	require.False(t, sampled, "lower-bound sampler should be out of balance")
+
+	s.update(20, 0)
+	sampled, _ = s.lowerBoundSampler.IsSampled(TraceID{})
+	assert.False(t, sampled, "update must not grant a fresh burst of lower-bound credit")
+}
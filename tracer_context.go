@@ -0,0 +1,34 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import "context"
+
+// CloseWithContext releases all resources used by the Tracer and flushes
+// any remaining buffered spans, the same as Close, but honors ctx so a
+// caller can bound total shutdown time (e.g. to fit inside a Kubernetes
+// SIGTERM grace period) and cancel an in-flight collector RPC.
+//
+// The sampler is always closed, even if the reporter fails to close within
+// ctx, so a canceled/expired ctx never leaks the sampler's background
+// goroutine (e.g. RemotelyControlledSampler.pollController).
+func (t *Tracer) CloseWithContext(ctx context.Context) error {
+	defer t.sampler.Close()
+	if reporter, ok := t.reporter.(ReporterWithContext); ok {
+		return reporter.CloseWithContext(ctx)
+	}
+	t.reporter.Close()
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"io"
+)
+
+// Transport abstracts the method of sending spans out of process.
+// Implementations are NOT required to be thread-safe; the RemoteReporter
+// is expected to only call methods on the Transport from the same go-routine.
+type Transport interface {
+	// Append converts the span to the wire representation and adds it
+	// to sender's internal buffer. If the buffer exceeds its designated
+	// size, the transport should flush and return the number of spans
+	// flushed, otherwise return 0.
+	Append(span *Span) (int, error)
+
+	// Flush submits the internal buffer to the remote server and returns
+	// the number of spans flushed.
+	Flush() (int, error)
+
+	io.Closer
+}
+
+// TransportWithContext is a context-aware variant of Transport. It lets a
+// caller bound how long Append/Flush/Close may take and cancel an
+// in-flight collector RPC, e.g. to honor a Kubernetes SIGTERM grace period
+// on shutdown.
+//
+// Built-in transports implement this interface; their plain Append/Flush/
+// Close methods remain available and simply delegate to the *WithContext
+// variants using context.Background(), so existing callers of the Transport
+// interface are unaffected.
+type TransportWithContext interface {
+	Transport
+
+	AppendWithContext(ctx context.Context, span *Span) (int, error)
+	FlushWithContext(ctx context.Context) (int, error)
+	CloseWithContext(ctx context.Context) error
+}
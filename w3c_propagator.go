@@ -0,0 +1,157 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// W3CTraceContextFormat is an OpenTracing carrier format constant for the
+// W3C Trace Context specification (https://www.w3.org/TR/trace-context/).
+// Register it with TracerOptions.Injector/Extractor to allow a tracer to
+// speak W3C alongside its native format.
+const W3CTraceContextFormat = "w3c-trace-context-format"
+
+const (
+	traceparentHeader  = "traceparent"
+	tracestateHeader   = "tracestate"
+	traceparentVersion = "00"
+	w3cVendorKey       = "jg"
+)
+
+// W3CPropagator is a combined Injector and Extractor for the W3C Trace
+// Context format. It propagates the traceparent header and passes the
+// tracestate header through unchanged for vendors other than this one,
+// updating only its own "jg=" entry.
+type W3CPropagator struct{}
+
+// NewW3CPropagator creates a combined Injector and Extractor for the W3C
+// Trace Context format.
+func NewW3CPropagator() *W3CPropagator {
+	return &W3CPropagator{}
+}
+
+// Inject implements Injector of W3CPropagator.
+func (p *W3CPropagator) Inject(sc SpanContext, abstractCarrier interface{}) error {
+	writer, ok := abstractCarrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	flags := byte(0)
+	if sc.IsSampled() {
+		flags = 1
+	}
+	writer.Set(traceparentHeader, fmt.Sprintf(
+		"%s-%016x%016x-%016x-%02x",
+		traceparentVersion,
+		sc.traceID.High,
+		sc.traceID.Low,
+		uint64(sc.spanID),
+		flags,
+	))
+	writer.Set(tracestateHeader, updateW3CVendorEntry(sc.traceState, sc.spanID))
+	return nil
+}
+
+// Extract implements Extractor of W3CPropagator.
+func (p *W3CPropagator) Extract(abstractCarrier interface{}) (SpanContext, error) {
+	reader, ok := abstractCarrier.(opentracing.TextMapReader)
+	if !ok {
+		return emptyContext, opentracing.ErrInvalidCarrier
+	}
+
+	var traceparent, tracestate string
+	err := reader.ForeachKey(func(rawKey, value string) error {
+		switch strings.ToLower(rawKey) {
+		case traceparentHeader:
+			traceparent = value
+		case tracestateHeader:
+			tracestate = value
+		}
+		return nil
+	})
+	if err != nil {
+		return emptyContext, err
+	}
+	if traceparent == "" {
+		return emptyContext, opentracing.ErrSpanContextNotFound
+	}
+
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return emptyContext, opentracing.ErrSpanContextCorrupted
+	}
+	traceID, err := TraceIDFromString(parts[1])
+	if err != nil || !traceID.IsValid() {
+		return emptyContext, opentracing.ErrSpanContextCorrupted
+	}
+	spanID, err := SpanIDFromString(parts[2])
+	if err != nil {
+		return emptyContext, opentracing.ErrSpanContextCorrupted
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return emptyContext, opentracing.ErrSpanContextCorrupted
+	}
+
+	ctx := SpanContext{
+		traceID:       traceID,
+		spanID:        spanID,
+		remote:        true,
+		samplingState: &samplingState{},
+		traceState:    tracestate,
+	}
+	if flags&1 == 1 {
+		ctx.samplingState.setSampled()
+	}
+	return ctx, nil
+}
+
+// updateW3CVendorEntry rewrites this tracer's entry in an existing
+// tracestate blob, preserving unknown vendor entries and their relative
+// order, and adding its own entry at the front if absent.
+func updateW3CVendorEntry(tracestate string, spanID SpanID) string {
+	ownEntry := fmt.Sprintf("%s=%016x", w3cVendorKey, uint64(spanID))
+	if tracestate == "" {
+		return ownEntry
+	}
+
+	entries := strings.Split(tracestate, ",")
+	out := make([]string, 0, len(entries)+1)
+	found := false
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if strings.HasPrefix(e, w3cVendorKey+"=") {
+			if !found {
+				out = append(out, ownEntry)
+				found = true
+			}
+			continue
+		}
+		out = append(out, e)
+	}
+	if !found {
+		out = append([]string{ownEntry}, out...)
+	}
+	return strings.Join(out, ",")
+}
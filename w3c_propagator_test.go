@@ -0,0 +1,81 @@
+// Copyright (c) 2023 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestW3CPropagatorRoundTrip(t *testing.T) {
+	p := NewW3CPropagator()
+	sc := NewSpanContext(TraceID{High: 1, Low: 2}, SpanID(3), SpanID(4), true, nil)
+
+	carrier := opentracing.HTTPHeadersCarrier(http.Header{})
+	require.NoError(t, p.Inject(sc, carrier))
+	assert.Equal(t, "00-00000000000000010000000000000002-0000000000000003-01", http.Header(carrier).Get(traceparentHeader))
+
+	extracted, err := p.Extract(carrier)
+	require.NoError(t, err)
+	assert.Equal(t, sc.traceID, extracted.traceID)
+	assert.Equal(t, sc.spanID, extracted.spanID)
+	assert.True(t, extracted.IsSampled())
+	assert.True(t, extracted.remote)
+}
+
+func TestW3CPropagatorUnknownVendorsRoundTrip(t *testing.T) {
+	p := NewW3CPropagator()
+	sc := NewSpanContext(TraceID{Low: 1}, SpanID(1), SpanID(0), true, nil)
+	sc = sc.WithTraceState("vendor1=foo,vendor2=bar")
+
+	carrier := opentracing.HTTPHeadersCarrier(http.Header{})
+	require.NoError(t, p.Inject(sc, carrier))
+
+	state := http.Header(carrier).Get(tracestateHeader)
+	assert.Contains(t, state, "jg=0000000000000001")
+	assert.Contains(t, state, "vendor1=foo")
+	assert.Contains(t, state, "vendor2=bar")
+
+	extracted, err := p.Extract(carrier)
+	require.NoError(t, err)
+	assert.Equal(t, state, extracted.TraceState())
+
+	// Injecting a new child span must update only the jaeger entry.
+	child := NewSpanContext(extracted.traceID, SpanID(2), extracted.spanID, true, nil).WithTraceState(extracted.TraceState())
+	childCarrier := opentracing.HTTPHeadersCarrier(http.Header{})
+	require.NoError(t, p.Inject(child, childCarrier))
+	childState := http.Header(childCarrier).Get(tracestateHeader)
+	assert.Contains(t, childState, "jg=0000000000000002")
+	assert.Contains(t, childState, "vendor1=foo")
+	assert.Contains(t, childState, "vendor2=bar")
+}
+
+func TestW3CPropagatorExtractNotFound(t *testing.T) {
+	p := NewW3CPropagator()
+	_, err := p.Extract(opentracing.HTTPHeadersCarrier(http.Header{}))
+	assert.Equal(t, opentracing.ErrSpanContextNotFound, err)
+}
+
+func TestW3CPropagatorExtractCorrupted(t *testing.T) {
+	p := NewW3CPropagator()
+	carrier := opentracing.HTTPHeadersCarrier(http.Header{})
+	carrier.Set(traceparentHeader, "garbage")
+	_, err := p.Extract(carrier)
+	assert.Equal(t, opentracing.ErrSpanContextCorrupted, err)
+}